@@ -1,7 +1,11 @@
 // Package gfn is a Golang library that leverages generics to provide various methods.
 package gfn
 
-import "math/rand"
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
 
 /* @example Contains
 gfn.Contains([]int{1, 2, 3}, 2)             // true
@@ -19,6 +23,34 @@ func Contains[T comparable](array []T, value T) bool {
 	return false
 }
 
+/* @example ContainsFunc
+gfn.ContainsFunc([][]int{{1, 2}, {3}}, []int{3}, func(a, b []int) bool {
+	return gfn.Equal(a, b)
+})  // true
+*/
+
+// ContainsFunc returns true if the array contains a value equal to the given
+// value, using eq to compare values. It is for types that are not comparable.
+func ContainsFunc[T any](array []T, value T, eq func(a, b T) bool) bool {
+	return IndexOfFunc(array, value, eq) >= 0
+}
+
+/* @example ContainsApprox
+a, b := 0.3, 0.1
+gfn.ContainsApprox([]float64{0.1, 0.2}, a-b, gfn.AbsTolerance(1e-9))  // true
+*/
+
+// ContainsApprox returns true if the array contains a value approximately
+// equal to the given value under the Tolerance, see AlmostEqual.
+func ContainsApprox[T Float](array []T, value T, tol Tolerance) bool {
+	for _, v := range array {
+		if AlmostEqual(v, value, tol) {
+			return true
+		}
+	}
+	return false
+}
+
 /* @example Range
 gfn.Range(0, 7)    // []int{0, 1, 2, 3, 4, 5, 6}
 gfn.Range(3, 8)    // []int{3, 4, 3, 6, 7}
@@ -70,6 +102,162 @@ func RangeBy[T Int | Uint](start, end, step T) []T {
 	return []T{}
 }
 
+/* @example Arange
+gfn.Arange(0.0, 1.0, 0.25)   // []float64{0, 0.25, 0.5, 0.75}
+gfn.Arange(1.0, 0.0, -0.5)   // []float64{1, 0.5}
+gfn.Arange(0.0, 1.0, -0.5)   // []float64{}
+*/
+
+// Arange returns a sequence of floats, starting from start, and
+// increments/decrements by step, until end is reached (not included).
+// The i-th value is computed as start + i*step, so rounding errors do not
+// accumulate. Like RangeBy, zero step panics and a step pointing away from
+// end returns an empty array. Non-finite start, end or step panics.
+// Because of rounding, a value very close to end may be included, use
+// Linspace when the number of values matters.
+func Arange[T Float](start, end, step T) []T {
+	if step == 0 {
+		panic("step must not be zero")
+	}
+	count := math.Ceil(float64(end-start) / float64(step))
+	if math.IsNaN(count) || math.IsInf(count, 0) {
+		panic("start, end and step must be finite")
+	}
+	if count <= 0 {
+		return []T{}
+	}
+
+	res := make([]T, int(count))
+	for i := range res {
+		res[i] = start + T(i)*step
+	}
+	return res
+}
+
+/* @example Linspace
+gfn.Linspace(0.0, 1.0, 5, true)   // []float64{0, 0.25, 0.5, 0.75, 1}
+gfn.Linspace(0.0, 1.0, 5, false)  // []float64{0, 0.2, 0.4, 0.6, 0.8}
+gfn.Linspace(1.0, 0.0, 3, true)   // []float64{1, 0.5, 0}
+*/
+
+// Linspace returns n evenly spaced floats over the interval [start, end],
+// or [start, end) if inclusive is false. When inclusive is true, the last
+// value is exactly end. Negative n panics.
+func Linspace[T Float](start, end T, n int, inclusive bool) []T {
+	if n < 0 {
+		panic("negative length")
+	}
+	res := make([]T, n)
+	if n == 0 {
+		return res
+	}
+
+	div := n
+	if inclusive {
+		div = n - 1
+	}
+	if div == 0 {
+		res[0] = start
+		return res
+	}
+	step := (end - start) / T(div)
+	for i := range res {
+		res[i] = start + T(i)*step
+	}
+	if inclusive {
+		res[n-1] = end
+	}
+	return res
+}
+
+/* @example Logspace
+gfn.Logspace(0.0, 3.0, 4, true, 10)  // []float64{1, 10, 100, 1000}
+gfn.Logspace(0.0, 3.0, 3, false, 2)  // []float64{1, 2, 4}
+*/
+
+// Logspace returns n floats evenly spaced on a log scale, from base^start
+// to base^end. The exponents are calculated by Linspace(start, end, n, inclusive).
+func Logspace[T Float](start, end T, n int, inclusive bool, base T) []T {
+	return Map(Linspace(start, end, n, inclusive), func(exp T) T {
+		return T(math.Pow(float64(base), float64(exp)))
+	})
+}
+
+/* @example Geomspace
+gfn.Geomspace(1.0, 1000.0, 4, true)   // []float64{1, 10, 100, 1000}
+gfn.Geomspace(-1.0, -16.0, 5, true)   // []float64{-1, -2, -4, -8, -16}
+gfn.Geomspace(1.0, 16.0, 4, false)    // []float64{1, 2, 4, 8}
+*/
+
+// Geomspace returns n floats forming a geometric progression from start
+// to end, or to end excluded if inclusive is false. When inclusive is true,
+// the last value is exactly end. If the ratio of the progression is an
+// integer or the inverse of one, like 2 or 1/10, all values are calculated
+// exactly when they can be represented. start and end must be non-zero and
+// have the same sign, otherwise it panics.
+func Geomspace[T Float](start, end T, n int, inclusive bool) []T {
+	if start == 0 || end == 0 {
+		panic("start and end must not be zero")
+	}
+	if (start < 0) != (end < 0) {
+		panic("start and end must have the same sign")
+	}
+
+	steps := n
+	if inclusive {
+		steps = n - 1
+	}
+	res, ok := exactGeomspace(start, end, n, steps)
+	if !ok {
+		sign := T(1)
+		if start < 0 {
+			sign = -1
+		}
+		logStart := math.Log(float64(start * sign))
+		logEnd := math.Log(float64(end * sign))
+		res = Map(Linspace(logStart, logEnd, n, inclusive), func(exp float64) T {
+			return sign * T(math.Exp(exp))
+		})
+	}
+	if n > 0 {
+		res[0] = start
+	}
+	if n > 1 && inclusive {
+		res[n-1] = end
+	}
+	return res
+}
+
+// exactGeomspace returns the progression of Geomspace by multiplying or
+// dividing start by an integer ratio, and false if the ratio is not an
+// integer or the inverse of one.
+func exactGeomspace[T Float](start, end T, n, steps int) ([]T, bool) {
+	if steps <= 0 {
+		return nil, false
+	}
+	q := float64(end) / float64(start)
+	divide := q < 1
+	if divide {
+		q = float64(start) / float64(end)
+	}
+	ratio := math.Round(math.Pow(q, 1/float64(steps)))
+	if ratio < 1 || math.Pow(ratio, float64(steps)) != q {
+		return nil, false
+	}
+
+	res := make([]T, n)
+	factor := 1.0
+	for i := range res {
+		if divide {
+			res[i] = T(float64(start) / factor)
+		} else {
+			res[i] = T(float64(start) * factor)
+		}
+		factor *= ratio
+	}
+	return res, true
+}
+
 /* @example Shuffle
 array := []int{1, 2, 3, 4}
 gfn.Shuffle(array)
@@ -104,6 +292,20 @@ func Equal[T comparable](a, b []T) bool {
 	return true
 }
 
+/* @example EqualApprox
+a, b := 0.1, 0.2
+gfn.EqualApprox([]float64{a + b, 1}, []float64{0.3, 1}, gfn.ULPTolerance(1))  // true
+gfn.EqualApprox([]float64{math.NaN()}, []float64{math.NaN()}, gfn.ULPTolerance(1))  // false
+*/
+
+// EqualApprox returns true if two arrays have the same length and their
+// elements are approximately equal under the Tolerance, see AlmostEqual.
+func EqualApprox[T Float](a, b []T, tol Tolerance) bool {
+	return EqualBy(a, b, func(x, y T) bool {
+		return AlmostEqual(x, y, tol)
+	})
+}
+
 /* @example EqualBy
 a := []int{1, 2, 3, 4, 5}
 b := []rune{'a', 'b', 'c', 'd', 'e'}
@@ -126,6 +328,94 @@ func EqualBy[T1, T2 any](a []T1, b []T2, fn func(T1, T2) bool) bool {
 	return true
 }
 
+/* @example EqualFunc
+gfn.EqualFunc([][]int{{1}, {2, 3}}, [][]int{{1}, {2, 3}}, func(a, b []int) bool {
+	return gfn.Equal(a, b)
+})  // true
+*/
+
+// EqualFunc returns true if two arrays are equal by comparing their elements
+// using eq. It is the same as EqualBy for arrays of the same element type.
+func EqualFunc[T any](a, b []T, eq func(a, b T) bool) bool {
+	return EqualBy(a, b, eq)
+}
+
+/* @example ElementsMatch
+gfn.ElementsMatch([]int{1, 2, 2, 3}, []int{2, 3, 1, 2})  // true
+gfn.ElementsMatch([]int{1, 2, 2}, []int{1, 1, 2})        // false
+*/
+
+// ElementsMatch returns true if two arrays contain the same elements with the
+// same number of occurrences, ignoring the order. Like map keys, NaN never
+// matches any element, including NaN.
+func ElementsMatch[T comparable](a, b []T) bool {
+	return ElementsMatchBy(a, b, func(v T) T { return v })
+}
+
+/* @example ElementsMatchBy
+type Employee struct {
+	name       string
+	department string
+}
+a := []Employee{{"Alice", "Accounting"}, {"Bob", "Engineering"}}
+b := []Employee{{"Dave", "Engineering"}, {"Carol", "Accounting"}}
+gfn.ElementsMatchBy(a, b, func(e Employee) string {
+	return e.department
+})  // true
+*/
+
+// ElementsMatchBy returns true if the keys of elements in two arrays, which
+// are returned by fn, match like ElementsMatch.
+func ElementsMatchBy[T any, U comparable](a, b []T, fn func(T) U) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counter := CounterBy(a, fn)
+	for _, v := range b {
+		key := fn(v)
+		if counter[key] == 0 {
+			return false
+		}
+		counter[key]--
+	}
+	return true
+}
+
+/* @example EqualAsSet
+gfn.EqualAsSet([]int{1, 2, 2, 3}, []int{3, 1, 2})  // true
+gfn.EqualAsSet([]int{1, 2}, []int{1, 2, 4})        // false
+*/
+
+// EqualAsSet returns true if two arrays contain the same distinct elements,
+// ignoring the order and duplicates. Like map keys, NaN never matches any
+// element, including NaN.
+func EqualAsSet[T comparable](a, b []T) bool {
+	return EqualAsSetBy(a, b, func(v T) T { return v })
+}
+
+/* @example EqualAsSetBy
+gfn.EqualAsSetBy([]string{"a", "B", "b"}, []string{"A", "b"}, strings.ToLower)  // true
+*/
+
+// EqualAsSetBy returns true if the keys of elements in two arrays, which are
+// returned by fn, match like EqualAsSet.
+func EqualAsSetBy[T any, U comparable](a, b []T, fn func(T) U) bool {
+	setA := make(map[U]struct{}, len(a))
+	for _, v := range a {
+		setA[fn(v)] = struct{}{}
+	}
+	setB := make(map[U]struct{}, len(b))
+	for _, v := range b {
+		key := fn(v)
+		if _, ok := setA[key]; !ok {
+			return false
+		}
+		setB[key] = struct{}{}
+	}
+	return len(setA) == len(setB)
+}
+
 /* @example ToSet
 gfn.ToSet([]int{0, 1, 2, 2, 2, 3, 3, 3, 4, 4, 4, 5, 5, 5})
 // map[int]struct{}{0: {}, 1: {}, 2: {}, 3: {}, 4: {}, 5: {}}
@@ -170,6 +460,85 @@ func IsSorted[T Int | Uint | Float | ~string](array []T) bool {
 	return true
 }
 
+/* @example ArgSort
+names := []string{"alice", "bob", "carol"}
+scores := []int{80, 95, 70}
+order := gfn.ArgSort(scores)  // []int{2, 0, 1}
+gfn.Map(order, func(i int) string {
+	return names[i]
+})  // []string{"carol", "alice", "bob"}
+*/
+
+// ArgSort returns the indexes that would sort the array in ascending order.
+// The sort is stable, equal values keep their relative order. For float64
+// arrays, NaN values are placed at the end.
+func ArgSort[T Int | Uint | Float | ~string](array []T) []int {
+	return ArgSortBy(array, lessNaNLast[T])
+}
+
+/* @example ArgSortBy
+gfn.ArgSortBy([]string{"abc", "d", "ef"}, func(a, b string) bool {
+	return len(a) > len(b)
+})  // []int{0, 2, 1}
+*/
+
+// ArgSortBy returns the indexes that would sort the array in the given
+// order. less should return true if a should be placed before b. The sort is
+// stable, equal values keep their relative order.
+func ArgSortBy[T any](array []T, less func(a, b T) bool) []int {
+	res := Range(0, len(array))
+	sort.SliceStable(res, func(i, j int) bool {
+		return less(array[res[i]], array[res[j]])
+	})
+	return res
+}
+
+/* @example Rank
+gfn.Rank([]int{30, 10, 20, 10})  // []int{4, 1, 3, 1}
+*/
+
+// Rank returns the rank of each value in ascending order, starting from 1.
+// Equal values get the same rank and leave a gap after them, like RANK in
+// SQL. For float64 arrays, NaN values are ranked after all other values and
+// are equal to each other.
+func Rank[T Int | Uint | Float | ~string](array []T) []int {
+	return rank(array, false)
+}
+
+/* @example DenseRank
+gfn.DenseRank([]int{30, 10, 20, 10})  // []int{3, 1, 2, 1}
+*/
+
+// DenseRank returns the rank of each value in ascending order, starting from
+// 1. Equal values get the same rank without gaps after them, like DENSE_RANK
+// in SQL. NaN values are handled like Rank.
+func DenseRank[T Int | Uint | Float | ~string](array []T) []int {
+	return rank(array, true)
+}
+
+func rank[T Int | Uint | Float | ~string](array []T, dense bool) []int {
+	order := ArgSort(array)
+	res := make([]int, len(array))
+	current := 0
+	for i, idx := range order {
+		// values are sorted, so a new rank starts when the previous one is smaller
+		if i == 0 || lessNaNLast(array[order[i-1]], array[idx]) {
+			if dense {
+				current++
+			} else {
+				current = i + 1
+			}
+		}
+		res[idx] = current
+	}
+	return res
+}
+
+// lessNaNLast is the ascending order of values with NaN placed last.
+func lessNaNLast[T Int | Uint | Float | ~string](a, b T) bool {
+	return !isNaN(a) && (isNaN(b) || a < b)
+}
+
 /* @example Counter
 gfn.Counter([]int{1, 2, 2, 2, 2})  // map[int]int{1: 1, 2: 4}
 */
@@ -291,6 +660,26 @@ func Uniq[T comparable](array []T) []T {
 	return res
 }
 
+/* @example UniqFunc
+gfn.UniqFunc([][]int{{1, 2}, {3}, {1, 2}}, func(a, b []int) bool {
+	return gfn.Equal(a, b)
+})  // [][]int{{1, 2}, {3}}
+*/
+
+// UniqFunc returns an array with all duplicates removed, using eq to compare
+// values. The first occurrence of every value is kept. Without hashing, every
+// value is compared with all kept values, so it takes O(n²) time, please use
+// Uniq or UniqBy when the values or a key of them are comparable.
+func UniqFunc[T any](array []T, eq func(a, b T) bool) []T {
+	res := []T{}
+	for _, v := range array {
+		if !ContainsFunc(res, v, eq) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
 /* @example UniqBy
 type Employee struct {
 	name       string
@@ -538,6 +927,178 @@ func GroupBy[T any, K comparable](array []T, groupFn func(T) K) map[K][]T {
 	return res
 }
 
+/* @example GroupByReduce
+type Employee struct {
+	name       string
+	department string
+	salary     int
+}
+employees := []Employee{
+	{"Alice", "Accounting", 100},
+	{"Bob", "Engineering", 200},
+	{"Cindy", "Engineering", 300},
+}
+gfn.GroupByReduce(employees, func(e Employee) string {
+	return e.department
+}, 0, func(total int, e Employee) int {
+	return total + e.salary
+})
+// map[string]int{"Accounting": 100, "Engineering": 500}
+*/
+
+// GroupByReduce groups the elements of an array according to groupFn and
+// reduces every group with fn in a single pass, without storing the groups.
+// Every group starts from init, so init should not be a pointer, map or
+// slice modified by fn, please use GroupByAggregate in that case.
+func GroupByReduce[T any, K comparable, R any](array []T, groupFn func(T) K, init R, fn func(R, T) R) map[K]R {
+	res := make(map[K]R)
+	for _, v := range array {
+		k := groupFn(v)
+		acc, ok := res[k]
+		if !ok {
+			acc = init
+		}
+		res[k] = fn(acc, v)
+	}
+	return res
+}
+
+/* @example GroupByAggregate
+type Employee struct {
+	name       string
+	department string
+	salary     int
+}
+employees := []Employee{
+	{"Alice", "Accounting", 100},
+	{"Bob", "Engineering", 200},
+	{"Cindy", "Engineering", 300},
+}
+type acc struct {
+	sum, count int
+}
+gfn.GroupByAggregate(employees, func(e Employee) string {
+	return e.department
+}, func() acc {
+	return acc{}
+}, func(a acc, e Employee) acc {
+	return acc{a.sum + e.salary, a.count + 1}
+}, func(a acc) float64 {
+	return float64(a.sum) / float64(a.count)
+})
+// map[string]float64{"Accounting": 100, "Engineering": 250}
+*/
+
+// GroupByAggregate groups the elements of an array according to groupFn and
+// aggregates every group in a single pass, without storing the groups. The
+// accumulator of every group is created by init, updated by accumulate for
+// every element of the group and converted to the result by finish.
+func GroupByAggregate[T any, K comparable, A any, R any](
+	array []T,
+	groupFn func(T) K,
+	init func() A,
+	accumulate func(A, T) A,
+	finish func(A) R,
+) map[K]R {
+	accs := make(map[K]A)
+	for _, v := range array {
+		k := groupFn(v)
+		acc, ok := accs[k]
+		if !ok {
+			acc = init()
+		}
+		accs[k] = accumulate(acc, v)
+	}
+
+	res := make(map[K]R, len(accs))
+	for k, acc := range accs {
+		res[k] = finish(acc)
+	}
+	return res
+}
+
+/* @example GroupBy2
+type Employee struct {
+	name       string
+	department string
+	level      string
+}
+employees := []Employee{
+	{"Alice", "Accounting", "junior"},
+	{"Bob", "Engineering", "senior"},
+	{"Cindy", "Engineering", "senior"},
+}
+gfn.GroupBy2(employees, func(e Employee) string {
+	return e.department
+}, func(e Employee) string {
+	return e.level
+})
+// map[string]map[string][]Employee{
+// 	"Accounting":  {"junior": {{"Alice", "Accounting", "junior"}}},
+// 	"Engineering": {"senior": {{"Bob", "Engineering", "senior"}, {"Cindy", "Engineering", "senior"}}},
+// }
+*/
+
+// GroupBy2 groups the elements of an array by two keys, the outer map is
+// keyed by groupFn1 and the inner maps by groupFn2. The elements of every
+// group keep their order in the array.
+func GroupBy2[T any, K1, K2 comparable](array []T, groupFn1 func(T) K1, groupFn2 func(T) K2) map[K1]map[K2][]T {
+	res := make(map[K1]map[K2][]T)
+	for _, v := range array {
+		k1, k2 := groupFn1(v), groupFn2(v)
+		if res[k1] == nil {
+			res[k1] = make(map[K2][]T)
+		}
+		res[k1][k2] = append(res[k1][k2], v)
+	}
+	return res
+}
+
+/* @example Pivot
+type Sale struct {
+	region  string
+	quarter string
+	amount  int
+}
+sales := []Sale{
+	{"east", "Q1", 10},
+	{"east", "Q1", 5},
+	{"east", "Q2", 20},
+	{"west", "Q2", 30},
+}
+gfn.Pivot(sales, func(s Sale) string {
+	return s.region
+}, func(s Sale) string {
+	return s.quarter
+}, 0, func(total int, s Sale) int {
+	return total + s.amount
+})
+// map[string]map[string]int{
+// 	"east": {"Q1": 15, "Q2": 20},
+// 	"west": {"Q2": 30},
+// }
+*/
+
+// Pivot builds a table of aggregated values, indexed by the row key and then
+// by the column key of the elements. Every cell reduces the elements with
+// its row and column keys like GroupByReduce, cells without elements are
+// absent from the table.
+func Pivot[T any, RK, CK comparable, V any](array []T, rowFn func(T) RK, colFn func(T) CK, init V, fn func(V, T) V) map[RK]map[CK]V {
+	res := make(map[RK]map[CK]V)
+	for _, v := range array {
+		row, col := rowFn(v), colFn(v)
+		if res[row] == nil {
+			res[row] = make(map[CK]V)
+		}
+		acc, ok := res[row][col]
+		if !ok {
+			acc = init
+		}
+		res[row][col] = fn(acc, v)
+	}
+	return res
+}
+
 /* @example IndexOf
 gfn.IndexOf([]int{1, 2, 3, 4}, 3)  // 2
 gfn.IndexOf([]int{1, 2, 3, 4}, 5)  // -1
@@ -554,6 +1115,23 @@ func IndexOf[T comparable](array []T, value T) int {
 	return -1
 }
 
+/* @example IndexOfFunc
+gfn.IndexOfFunc([][]int{{1, 2}, {3}}, []int{3}, func(a, b []int) bool {
+	return gfn.Equal(a, b)
+})  // 1
+*/
+
+// IndexOfFunc returns the index of the first value in an array equal to the
+// given value, using eq to compare values, or -1 if not found.
+func IndexOfFunc[T any](array []T, value T, eq func(a, b T) bool) int {
+	for i, v := range array {
+		if eq(v, value) {
+			return i
+		}
+	}
+	return -1
+}
+
 /* @example LastIndexOf
 gfn.LastIndexOf([]int{3, 3, 3, 4}, 3)  // 2
 gfn.LastIndexOf([]int{1, 2, 3, 4}, 5)  // -1
@@ -623,7 +1201,15 @@ gfn.Concat([]int{1, 2}, []int{3, 4})  // []int{1, 2, 3, 4}
 
 // Concat returns a new array that is the result of joining two or more arrays.
 func Concat[T any](arrays ...[]T) []T {
-	var res []T
+	size := 0
+	for _, array := range arrays {
+		size += len(array)
+	}
+	if size == 0 {
+		return nil
+	}
+
+	res := make([]T, 0, size)
 	for _, array := range arrays {
 		res = append(res, array...)
 	}
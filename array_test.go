@@ -1,9 +1,11 @@
 package gfn_test
 
 import (
+	"math"
 	"math/rand"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -87,6 +89,88 @@ func TestRangeBy(t *testing.T) {
 	})
 }
 
+func TestArange(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{0, 0.25, 0.5, 0.75}, Arange(0.0, 1.0, 0.25))
+	AssertFloatSliceEqual(t, []float64{1, 0.5}, Arange(1.0, 0.0, -0.5))
+	AssertFloatSliceEqual(t, []float64{0, 0.3, 0.6, 0.9}, Arange(0.0, 1.0, 0.3))
+	AssertFloatSliceEqual(t, []float64{-1, -0.5, 0, 0.5}, Arange(-1.0, 1.0, 0.5))
+	AssertFloatSliceEqual(t, []float32{0, 0.5, 1, 1.5}, Arange[float32](0, 2, 0.5))
+	AssertFloatSliceEqual(t, []float64{}, Arange(0.0, 1.0, -0.5))
+	AssertFloatSliceEqual(t, []float64{}, Arange(1.0, 0.0, 0.5))
+	AssertFloatSliceEqual(t, []float64{}, Arange(1.0, 1.0, 0.5))
+
+	// no accumulated error
+	res := Arange(0.0, 100.0, 0.1)
+	AssertEqual(t, 1000, len(res))
+	AssertEqual(t, 99.9, res[999])
+
+	AssertPanics(t, func() {
+		Arange(0.0, 1.0, 0.0)
+	})
+	AssertPanics(t, func() {
+		Arange(0.0, math.Inf(1), 1.0)
+	})
+	AssertPanics(t, func() {
+		Arange(math.NaN(), 1.0, 1.0)
+	})
+}
+
+func TestLinspace(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{0, 0.25, 0.5, 0.75, 1}, Linspace(0.0, 1.0, 5, true))
+	AssertFloatSliceEqual(t, []float64{0, 0.2, 0.4, 0.6, 0.8}, Linspace(0.0, 1.0, 5, false))
+	AssertFloatSliceEqual(t, []float64{1, 0.5, 0}, Linspace(1.0, 0.0, 3, true))
+	AssertFloatSliceEqual(t, []float32{2, 2, 2}, Linspace[float32](2, 2, 3, true))
+	AssertFloatSliceEqual(t, []float64{3}, Linspace(3.0, 5.0, 1, true))
+	AssertFloatSliceEqual(t, []float64{3}, Linspace(3.0, 5.0, 1, false))
+	AssertFloatSliceEqual(t, []float64{}, Linspace(3.0, 5.0, 0, true))
+
+	// end is exact
+	res := Linspace(0.0, 0.3, 7, true)
+	AssertEqual(t, 0.3, res[6])
+
+	AssertPanics(t, func() {
+		Linspace(0.0, 1.0, -1, true)
+	})
+}
+
+func TestLogspace(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{1, 10, 100, 1000}, Logspace(0.0, 3.0, 4, true, 10))
+	AssertFloatSliceEqual(t, []float64{1, 2, 4}, Logspace(0.0, 3.0, 3, false, 2))
+	AssertFloatSliceEqual(t, []float64{0.01, 0.1, 1}, Logspace(-2.0, 0.0, 3, true, 10))
+	AssertFloatSliceEqual(t, []float32{}, Logspace[float32](0, 3, 0, true, 10))
+}
+
+func TestGeomspace(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{1, 10, 100, 1000}, Geomspace(1.0, 1000.0, 4, true))
+	AssertFloatSliceEqual(t, []float64{-1, -2, -4, -8, -16}, Geomspace(-1.0, -16.0, 5, true))
+	AssertFloatSliceEqual(t, []float64{1, 2, 4, 8}, Geomspace(1.0, 16.0, 4, false))
+	AssertFloatSliceEqual(t, []float64{16, 4, 1}, Geomspace(16.0, 1.0, 3, true))
+	AssertFloatSliceEqual(t, []float32{5}, Geomspace[float32](5, 10, 1, true))
+	AssertFloatSliceEqual(t, []float64{}, Geomspace(1.0, 10.0, 0, true))
+
+	// integer ratios and their inverses are exact
+	AssertSliceEqual(t, []float64{1, 10, 100, 1000}, Geomspace(1.0, 1000.0, 4, true))
+	AssertSliceEqual(t, []float64{-1, -2, -4, -8, -16}, Geomspace(-1.0, -16.0, 5, true))
+	AssertSliceEqual(t, []float64{1, 2, 4, 8}, Geomspace(1.0, 16.0, 4, false))
+	AssertSliceEqual(t, []float64{1000, 100, 10, 1}, Geomspace(1000.0, 1.0, 4, true))
+	AssertSliceEqual(t, []float64{3, 9, 27}, Geomspace(3.0, 81.0, 3, false))
+	AssertSliceEqual(t, []float32{5, 5, 5}, Geomspace[float32](5, 5, 3, true))
+	AssertSliceEqual(t, []float32{0.5, 1, 2, 4}, Geomspace[float32](0.5, 4, 4, true))
+	AssertFloatSliceEqual(t, []float64{1, 1.4142, 2}, Geomspace(1.0, 2.0, 3, true))
+
+	// endpoints are exact
+	res := Geomspace(0.3, 0.7, 9, true)
+	AssertEqual(t, 0.3, res[0])
+	AssertEqual(t, 0.7, res[8])
+
+	AssertPanics(t, func() {
+		Geomspace(0.0, 1.0, 3, true)
+	})
+	AssertPanics(t, func() {
+		Geomspace(-1.0, 1.0, 3, true)
+	})
+}
+
 func TestShuffle(t *testing.T) {
 	array := Range(0, 200000)
 	Shuffle(array)
@@ -356,6 +440,7 @@ func TestConcat(t *testing.T) {
 	AssertSliceEqual(t, []int{1, 2, 3, 4, 5}, Concat([]int{1, 2}, []int{3}, []int{4, 5}))
 	AssertSliceEqual(t, []int{1, 2, 3, 4, 5}, Concat([]int{1, 2}, []int{3}, []int{4}, []int{5}))
 	AssertSliceEqual(t, []int{1, 2, 3, 4, 5}, Concat([]int{1, 2}, []int{}, []int{3}, []int{4}, []int{}, []int{5}))
+	AssertSliceEqual(t, nil, Concat([]int{}, []int{}))
 }
 
 func TestFind(t *testing.T) {
@@ -615,3 +700,246 @@ func TestEqualBy(t *testing.T) {
 		}))
 	}
 }
+
+func TestArgSort(t *testing.T) {
+	AssertSliceEqual(t, []int{2, 0, 1}, ArgSort([]int{80, 95, 70}))
+	AssertSliceEqual(t, []int{1, 3, 2, 0}, ArgSort([]int{3, 1, 2, 1}))
+	AssertSliceEqual(t, []int{1, 2, 0}, ArgSort([]string{"c", "a", "b"}))
+	AssertSliceEqual(t, []int{}, ArgSort([]int{}))
+	AssertSliceEqual(t, []int{3, 1, 0, 2}, ArgSort([]float64{2, 1, math.NaN(), math.Inf(-1)}))
+
+	// sorting by indexes produces a sorted array
+	for i := 0; i < 100; i++ {
+		array := make([]int, rand.Intn(50))
+		for j := range array {
+			array[j] = rand.Intn(10)
+		}
+		sorted := Map(ArgSort(array), func(j int) int {
+			return array[j]
+		})
+		AssertTrue(t, IsSorted(sorted))
+	}
+}
+
+func TestArgSortBy(t *testing.T) {
+	AssertSliceEqual(t, []int{0, 2, 1}, ArgSortBy([]string{"abc", "d", "ef"}, func(a, b string) bool {
+		return len(a) > len(b)
+	}))
+	AssertSliceEqual(t, []int{0, 1, 2}, ArgSortBy([]string{"a", "b", "c"}, func(a, b string) bool {
+		return len(a) < len(b)
+	}))
+	AssertSliceEqual(t, []int{}, ArgSortBy([]int{}, func(a, b int) bool {
+		return a < b
+	}))
+}
+
+func TestRank(t *testing.T) {
+	AssertSliceEqual(t, []int{4, 1, 3, 1}, Rank([]int{30, 10, 20, 10}))
+	AssertSliceEqual(t, []int{1, 1, 1}, Rank([]string{"a", "a", "a"}))
+	AssertSliceEqual(t, []int{}, Rank([]int{}))
+	AssertSliceEqual(t, []int{2, 3, 3, 1}, Rank([]float64{1, math.NaN(), math.NaN(), 0}))
+}
+
+func TestDenseRank(t *testing.T) {
+	AssertSliceEqual(t, []int{3, 1, 2, 1}, DenseRank([]int{30, 10, 20, 10}))
+	AssertSliceEqual(t, []int{1, 1, 2}, DenseRank([]uint{5, 5, 7}))
+	AssertSliceEqual(t, []int{}, DenseRank([]int{}))
+	AssertSliceEqual(t, []int{2, 3, 3, 1}, DenseRank([]float64{1, math.NaN(), math.NaN(), 0}))
+}
+
+func sliceEqual(a, b []int) bool {
+	return Equal(a, b)
+}
+
+func TestContainsFunc(t *testing.T) {
+	AssertTrue(t, ContainsFunc([][]int{{1, 2}, {3}}, []int{3}, sliceEqual))
+	AssertFalse(t, ContainsFunc([][]int{{1, 2}, {3}}, []int{2, 1}, sliceEqual))
+	AssertFalse(t, ContainsFunc([][]int{}, []int{}, sliceEqual))
+}
+
+func TestEqualFunc(t *testing.T) {
+	AssertTrue(t, EqualFunc([][]int{{1}, {2, 3}}, [][]int{{1}, {2, 3}}, sliceEqual))
+	AssertFalse(t, EqualFunc([][]int{{1}, {2, 3}}, [][]int{{1}, {3, 2}}, sliceEqual))
+	AssertFalse(t, EqualFunc([][]int{{1}}, [][]int{{1}, {1}}, sliceEqual))
+	AssertTrue(t, EqualFunc([][]int{}, [][]int{}, sliceEqual))
+}
+
+func TestUniqFunc(t *testing.T) {
+	res := UniqFunc([][]int{{1, 2}, {3}, {1, 2}, {}, {3}}, sliceEqual)
+	AssertEqual(t, 3, len(res))
+	AssertSliceEqual(t, []int{1, 2}, res[0])
+	AssertSliceEqual(t, []int{3}, res[1])
+	AssertSliceEqual(t, []int{}, res[2])
+	AssertEqual(t, 0, len(UniqFunc([][]int{}, sliceEqual)))
+
+	// keeps the first occurrence
+	AssertSliceEqual(t, []string{"a", "B"}, UniqFunc([]string{"a", "B", "A", "b"}, strings.EqualFold))
+}
+
+func TestIndexOfFunc(t *testing.T) {
+	AssertEqual(t, 1, IndexOfFunc([][]int{{1, 2}, {3}, {3}}, []int{3}, sliceEqual))
+	AssertEqual(t, -1, IndexOfFunc([][]int{{1, 2}, {3}}, []int{4}, sliceEqual))
+	AssertEqual(t, 0, IndexOfFunc([]string{"Go", "go"}, "GO", strings.EqualFold))
+}
+
+func TestContainsApprox(t *testing.T) {
+	a, b := 0.3, 0.1
+	AssertTrue(t, ContainsApprox([]float64{0.1, 0.2}, a-b, AbsTolerance(1e-9)))
+	AssertFalse(t, Contains([]float64{0.1, 0.2}, a-b))
+	AssertFalse(t, ContainsApprox([]float64{0.1, 0.2}, 0.3, AbsTolerance(1e-9)))
+	AssertFalse(t, ContainsApprox([]float64{math.NaN()}, math.NaN(), ULPTolerance(1)))
+	AssertTrue(t, ContainsApprox([]float64{1, math.NaN()}, math.NaN(), ULPTolerance(1).WithNaNEqual()))
+	AssertFalse(t, ContainsApprox([]float32{}, 1, AbsTolerance(1)))
+}
+
+func TestEqualApprox(t *testing.T) {
+	a, b := 0.1, 0.2
+	AssertTrue(t, EqualApprox([]float64{a + b, 1}, []float64{0.3, 1}, ULPTolerance(1)))
+	AssertFalse(t, Equal([]float64{a + b, 1}, []float64{0.3, 1}))
+	AssertFalse(t, EqualApprox([]float64{0.3}, []float64{0.3, 0.3}, AbsTolerance(1)))
+	AssertFalse(t, EqualApprox([]float64{math.NaN()}, []float64{math.NaN()}, ULPTolerance(1)))
+	AssertTrue(t, EqualApprox([]float64{math.NaN()}, []float64{math.NaN()}, ULPTolerance(1).WithNaNEqual()))
+	AssertTrue(t, EqualApprox([]float32{}, []float32{}, AbsTolerance(0)))
+}
+
+func TestElementsMatch(t *testing.T) {
+	AssertTrue(t, ElementsMatch([]int{1, 2, 2, 3}, []int{2, 3, 1, 2}))
+	AssertFalse(t, ElementsMatch([]int{1, 2, 2}, []int{1, 1, 2}))
+	AssertFalse(t, ElementsMatch([]int{1, 2}, []int{1, 2, 2}))
+	AssertTrue(t, ElementsMatch([]string{}, []string{}))
+	AssertTrue(t, ElementsMatch(nil, []int{}))
+	AssertFalse(t, ElementsMatch([]float64{math.NaN()}, []float64{math.NaN()}))
+
+	// keys of a map in any order
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	AssertTrue(t, ElementsMatch([]string{"c", "a", "b"}, Keys(m)))
+}
+
+func TestElementsMatchBy(t *testing.T) {
+	type Employee struct {
+		name       string
+		department string
+	}
+	department := func(e Employee) string {
+		return e.department
+	}
+	a := []Employee{{"Alice", "Accounting"}, {"Bob", "Engineering"}}
+	b := []Employee{{"Dave", "Engineering"}, {"Carol", "Accounting"}}
+	c := []Employee{{"Dave", "Engineering"}, {"Eve", "Engineering"}}
+	AssertTrue(t, ElementsMatchBy(a, b, department))
+	AssertFalse(t, ElementsMatchBy(a, c, department))
+	AssertFalse(t, ElementsMatchBy(a, a[:1], department))
+}
+
+func TestEqualAsSet(t *testing.T) {
+	AssertTrue(t, EqualAsSet([]int{1, 2, 2, 3}, []int{3, 1, 2}))
+	AssertFalse(t, EqualAsSet([]int{1, 2}, []int{1, 2, 4}))
+	AssertFalse(t, EqualAsSet([]int{1, 2, 4}, []int{1, 2}))
+	AssertFalse(t, EqualAsSet([]int{1, 1}, []int{1, 2}))
+	AssertTrue(t, EqualAsSet([]int{}, nil))
+	AssertFalse(t, EqualAsSet([]float64{math.NaN()}, []float64{math.NaN()}))
+}
+
+func TestEqualAsSetBy(t *testing.T) {
+	AssertTrue(t, EqualAsSetBy([]string{"a", "B", "b"}, []string{"A", "b"}, strings.ToLower))
+	AssertFalse(t, EqualAsSetBy([]string{"a", "B"}, []string{"A", "c"}, strings.ToLower))
+	AssertTrue(t, EqualAsSetBy([]int{1, 3, 5}, []int{7}, func(i int) int {
+		return i % 2
+	}))
+}
+
+type groupEmployee struct {
+	name       string
+	department string
+	level      string
+	salary     int
+}
+
+var groupEmployees = []groupEmployee{
+	{"Alice", "Accounting", "junior", 100},
+	{"Bob", "Engineering", "senior", 200},
+	{"Cindy", "Engineering", "senior", 300},
+	{"Dave", "Engineering", "junior", 150},
+}
+
+func department(e groupEmployee) string {
+	return e.department
+}
+
+func TestGroupByReduce(t *testing.T) {
+	AssertMapEqual(t, map[string]int{"Accounting": 100, "Engineering": 650}, GroupByReduce(groupEmployees, department, 0, func(total int, e groupEmployee) int {
+		return total + e.salary
+	}))
+	AssertMapEqual(t, map[string]string{"Accounting": "Alice", "Engineering": "Bob,Cindy,Dave"}, GroupByReduce(groupEmployees, department, "", func(names string, e groupEmployee) string {
+		if names == "" {
+			return e.name
+		}
+		return names + "," + e.name
+	}))
+	AssertMapEqual(t, map[string]int{}, GroupByReduce([]groupEmployee{}, department, 0, func(total int, e groupEmployee) int {
+		return total + e.salary
+	}))
+}
+
+func TestGroupByAggregate(t *testing.T) {
+	type acc struct {
+		sum, count int
+	}
+	AssertMapEqual(t, map[string]float64{"Accounting": 100, "Engineering": 650.0 / 3}, GroupByAggregate(groupEmployees, department, func() acc {
+		return acc{}
+	}, func(a acc, e groupEmployee) acc {
+		return acc{a.sum + e.salary, a.count + 1}
+	}, func(a acc) float64 {
+		return float64(a.sum) / float64(a.count)
+	}))
+
+	// every group gets its own accumulator
+	sets := GroupByAggregate(groupEmployees, department, func() map[string]struct{} {
+		return make(map[string]struct{})
+	}, func(set map[string]struct{}, e groupEmployee) map[string]struct{} {
+		set[e.level] = struct{}{}
+		return set
+	}, func(set map[string]struct{}) int {
+		return len(set)
+	})
+	AssertMapEqual(t, map[string]int{"Accounting": 1, "Engineering": 2}, sets)
+}
+
+func TestGroupBy2(t *testing.T) {
+	groups := GroupBy2(groupEmployees, department, func(e groupEmployee) string {
+		return e.level
+	})
+	AssertEqual(t, 2, len(groups))
+	AssertEqual(t, 1, len(groups["Accounting"]))
+	AssertSliceEqual(t, []groupEmployee{groupEmployees[0]}, groups["Accounting"]["junior"])
+	AssertEqual(t, 2, len(groups["Engineering"]))
+	AssertSliceEqual(t, groupEmployees[1:3], groups["Engineering"]["senior"])
+	AssertSliceEqual(t, groupEmployees[3:], groups["Engineering"]["junior"])
+
+	AssertEqual(t, 0, len(GroupBy2([]int{}, func(i int) int {
+		return i
+	}, func(i int) int {
+		return i
+	})))
+}
+
+func TestPivot(t *testing.T) {
+	table := Pivot(groupEmployees, department, func(e groupEmployee) string {
+		return e.level
+	}, 0, func(total int, e groupEmployee) int {
+		return total + e.salary
+	})
+	AssertEqual(t, 2, len(table))
+	AssertMapEqual(t, map[string]int{"junior": 100}, table["Accounting"])
+	AssertMapEqual(t, map[string]int{"senior": 500, "junior": 150}, table["Engineering"])
+
+	counts := Pivot([]int{1, 2, 3, 4, 5, 6}, func(i int) bool {
+		return i%2 == 0
+	}, func(i int) bool {
+		return i > 3
+	}, 0, func(count int, _ int) int {
+		return count + 1
+	})
+	AssertMapEqual(t, map[bool]int{false: 2, true: 1}, counts[false])
+	AssertMapEqual(t, map[bool]int{false: 1, true: 2}, counts[true])
+}
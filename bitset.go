@@ -0,0 +1,204 @@
+package gfn
+
+import "math/bits"
+
+const wordSize = 64
+
+// Bitset is a set of non-negative integers stored as bits, which is much
+// more compact than map[uint]struct{} for dense small integers such as IDs
+// and flags. The zero value is an empty bitset ready to use.
+// Bitset is not safe for concurrent use.
+type Bitset struct {
+	words []uint64
+}
+
+/* @example ToBitset
+b := gfn.ToBitset([]int{1, 3, 5, 3})
+b.Test(3)     // true
+b.Count()     // 3
+b.ToSlice()   // []uint{1, 3, 5}
+*/
+
+// ToBitset converts an array of integers to a bitset. It panics if any
+// value is negative.
+func ToBitset[T Int | Uint](array []T) *Bitset {
+	var size uint
+	for _, v := range array {
+		if v < 0 {
+			panic("negative value")
+		}
+		if uint(v) >= size {
+			size = uint(v) + 1
+		}
+	}
+	b := NewBitset(size)
+	for _, v := range array {
+		b.Set(uint(v))
+	}
+	return b
+}
+
+/* @example NewBitset
+b := gfn.NewBitset(1000)  // pre-allocate space for 0 ~ 999
+b.Set(10)
+b.Set(999)
+b.Set(2000)  // bitset grows automatically
+*/
+
+// NewBitset returns an empty bitset with space pre-allocated for values in [0, size).
+func NewBitset(size uint) *Bitset {
+	return &Bitset{words: make([]uint64, (size+wordSize-1)/wordSize)}
+}
+
+// Set adds i to the bitset.
+func (b *Bitset) Set(i uint) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		// append grows the capacity geometrically, so setting ascending
+		// values is amortized O(1)
+		b.words = append(b.words, make([]uint64, w+1-uint(len(b.words)))...)
+	}
+	b.words[w] |= 1 << (i % wordSize)
+}
+
+// Clear removes i from the bitset.
+func (b *Bitset) Clear(i uint) {
+	w := i / wordSize
+	if w < uint(len(b.words)) {
+		b.words[w] &^= 1 << (i % wordSize)
+	}
+}
+
+// Test returns true if i is in the bitset.
+func (b *Bitset) Test(i uint) bool {
+	w := i / wordSize
+	return w < uint(len(b.words)) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// Count returns the number of values in the bitset.
+func (b *Bitset) Count() int {
+	res := 0
+	for _, w := range b.words {
+		res += bits.OnesCount64(w)
+	}
+	return res
+}
+
+/* @example Bitset.NextSet
+b := gfn.ToBitset([]uint{1, 10, 100})
+b.NextSet(0)    // 1, true
+b.NextSet(2)    // 10, true
+b.NextSet(101)  // 0, false
+
+// iterate in ascending order
+for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+	fmt.Println(i)
+}
+*/
+
+// NextSet returns the smallest value in the bitset that is greater than or
+// equal to i. It returns false if there is no such value.
+func (b *Bitset) NextSet(i uint) (uint, bool) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return 0, false
+	}
+	word := b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < uint(len(b.words)); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// ForEach calls fn for each value in the bitset in ascending order.
+func (b *Bitset) ForEach(fn func(i uint)) {
+	for w, word := range b.words {
+		for word != 0 {
+			fn(uint(w)*wordSize + uint(bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+}
+
+// ToSlice returns the values in the bitset in ascending order.
+func (b *Bitset) ToSlice() []uint {
+	res := make([]uint, 0, b.Count())
+	b.ForEach(func(i uint) {
+		res = append(res, i)
+	})
+	return res
+}
+
+// Copy returns a copy of the bitset.
+func (b *Bitset) Copy() *Bitset {
+	return &Bitset{words: Copy(b.words)}
+}
+
+// Equal returns true if two bitsets contain the same values.
+func (b *Bitset) Equal(other *Bitset) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+/* @example Bitset.Union
+a := gfn.ToBitset([]int{1, 2, 3})
+b := gfn.ToBitset([]int{3, 4})
+a.Union(b).ToSlice()         // []uint{1, 2, 3, 4}
+a.Intersection(b).ToSlice()  // []uint{3}
+a.Difference(b).ToSlice()    // []uint{1, 2}
+a.Xor(b).ToSlice()           // []uint{1, 2, 4}
+*/
+
+// Union returns a new bitset containing values in either bitset.
+func (b *Bitset) Union(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Intersection returns a new bitset containing values in both bitsets.
+func (b *Bitset) Intersection(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference returns a new bitset containing values in b but not in other.
+func (b *Bitset) Difference(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// Xor returns a new bitset containing values in exactly one of the bitsets.
+func (b *Bitset) Xor(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// combine applies op word by word, missing words are treated as zero.
+func (b *Bitset) combine(other *Bitset, op func(x, y uint64) uint64) *Bitset {
+	res := &Bitset{words: make([]uint64, Max(len(b.words), len(other.words)))}
+	for i := range res.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(other.words) {
+			y = other.words[i]
+		}
+		res.words[i] = op(x, y)
+	}
+	return res
+}
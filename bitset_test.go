@@ -0,0 +1,157 @@
+package gfn_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func TestToBitset(t *testing.T) {
+	b := ToBitset([]int{1, 3, 5, 3})
+	AssertTrue(t, b.Test(3))
+	AssertFalse(t, b.Test(2))
+	AssertFalse(t, b.Test(1000))
+	AssertEqual(t, 3, b.Count())
+	AssertSliceEqual(t, []uint{1, 3, 5}, b.ToSlice())
+
+	AssertSliceEqual(t, []uint{0, 64, 128}, ToBitset([]uint8{128, 64, 0}).ToSlice())
+	AssertSliceEqual(t, []uint{}, ToBitset([]int{}).ToSlice())
+	AssertEqual(t, 1<<21, ToBitset(Range(0, 1<<21)).Count())
+	AssertPanics(t, func() {
+		ToBitset([]int8{1, -1})
+	})
+
+	// compare with set
+	for i := 0; i < 100; i++ {
+		array := make([]uint, rand.Intn(100))
+		for j := range array {
+			array[j] = uint(rand.Intn(500))
+		}
+		expected := Keys(ToSet(array))
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+		b := ToBitset(array)
+		AssertSliceEqual(t, expected, b.ToSlice())
+		AssertEqual(t, len(expected), b.Count())
+	}
+}
+
+func TestNewBitset(t *testing.T) {
+	var zero Bitset
+	AssertEqual(t, 0, zero.Count())
+	zero.Set(100)
+	AssertTrue(t, zero.Test(100))
+
+	// growing by ascending values is not quadratic
+	for i := uint(0); i < 1<<21; i++ {
+		zero.Set(i)
+	}
+	AssertEqual(t, 1<<21, zero.Count())
+
+	b := NewBitset(100)
+	AssertEqual(t, 0, b.Count())
+	b.Set(10)
+	b.Set(99)
+	b.Set(2000)
+	AssertSliceEqual(t, []uint{10, 99, 2000}, b.ToSlice())
+
+	b.Clear(99)
+	b.Clear(5000)
+	AssertFalse(t, b.Test(99))
+	AssertSliceEqual(t, []uint{10, 2000}, b.ToSlice())
+
+	c := b.Copy()
+	c.Set(1)
+	AssertFalse(t, b.Test(1))
+	AssertTrue(t, c.Test(1))
+
+	AssertTrue(t, NewBitset(1000).Equal(&Bitset{}))
+	AssertTrue(t, ToBitset([]int{1, 2}).Equal(ToBitset([]int{2, 1})))
+	b = NewBitset(1000)
+	b.Set(1)
+	AssertTrue(t, b.Equal(ToBitset([]int{1})))
+	AssertTrue(t, ToBitset([]int{1}).Equal(b))
+	AssertFalse(t, b.Equal(ToBitset([]int{1, 999})))
+	AssertFalse(t, ToBitset([]int{1, 999}).Equal(b))
+	AssertFalse(t, ToBitset([]int{2}).Equal(b))
+}
+
+func TestBitsetNextSet(t *testing.T) {
+	b := ToBitset([]uint{1, 10, 100, 640})
+	next, ok := b.NextSet(0)
+	AssertTrue(t, ok)
+	AssertEqual(t, uint(1), next)
+	next, ok = b.NextSet(1)
+	AssertTrue(t, ok)
+	AssertEqual(t, uint(1), next)
+	next, ok = b.NextSet(11)
+	AssertTrue(t, ok)
+	AssertEqual(t, uint(100), next)
+	next, ok = b.NextSet(101)
+	AssertTrue(t, ok)
+	AssertEqual(t, uint(640), next)
+	_, ok = b.NextSet(641)
+	AssertFalse(t, ok)
+	_, ok = b.NextSet(10000)
+	AssertFalse(t, ok)
+
+	res := []uint{}
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		res = append(res, i)
+	}
+	AssertSliceEqual(t, []uint{1, 10, 100, 640}, res)
+
+	res = []uint{}
+	b.ForEach(func(i uint) {
+		res = append(res, i)
+	})
+	AssertSliceEqual(t, []uint{1, 10, 100, 640}, res)
+}
+
+func TestBitsetUnion(t *testing.T) {
+	a := ToBitset([]int{1, 2, 3, 200})
+	b := ToBitset([]int{3, 4})
+	AssertSliceEqual(t, []uint{1, 2, 3, 4, 200}, a.Union(b).ToSlice())
+	AssertSliceEqual(t, []uint{1, 2, 3, 4, 200}, b.Union(a).ToSlice())
+	AssertSliceEqual(t, []uint{3}, a.Intersection(b).ToSlice())
+	AssertSliceEqual(t, []uint{3}, b.Intersection(a).ToSlice())
+	AssertSliceEqual(t, []uint{1, 2, 200}, a.Difference(b).ToSlice())
+	AssertSliceEqual(t, []uint{4}, b.Difference(a).ToSlice())
+	AssertSliceEqual(t, []uint{1, 2, 4, 200}, a.Xor(b).ToSlice())
+	AssertSliceEqual(t, []uint{1, 2, 4, 200}, b.Xor(a).ToSlice())
+
+	// operands are not modified
+	AssertSliceEqual(t, []uint{1, 2, 3, 200}, a.ToSlice())
+	AssertSliceEqual(t, []uint{3, 4}, b.ToSlice())
+
+	// compare with slice functions
+	for i := 0; i < 100; i++ {
+		x := make([]uint, rand.Intn(100))
+		for j := range x {
+			x[j] = uint(rand.Intn(300))
+		}
+		y := make([]uint, rand.Intn(100))
+		for j := range y {
+			y[j] = uint(rand.Intn(300))
+		}
+		bx, by := ToBitset(x), ToBitset(y)
+		AssertTrue(t, bx.Union(by).Equal(ToBitset(Union(x, y))))
+		AssertTrue(t, bx.Intersection(by).Equal(ToBitset(Intersection(x, y))))
+		AssertTrue(t, bx.Difference(by).Equal(ToBitset(Difference(x, y))))
+	}
+}
+
+func BenchmarkToBitset(b *testing.B) {
+	array := Range(0, 1<<20)
+	for i := 0; i < b.N; i++ {
+		ToBitset(array)
+	}
+}
+
+func BenchmarkBitsetSet(b *testing.B) {
+	bitset := &Bitset{}
+	for i := 0; i < b.N; i++ {
+		bitset.Set(uint(i))
+	}
+}
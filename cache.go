@@ -0,0 +1,559 @@
+package gfn
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// cacheEntry is an element of an entryList.
+type cacheEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	expire time.Time
+	freq   int
+	prev   *cacheEntry[K, V]
+	next   *cacheEntry[K, V]
+}
+
+// entryList is a doubly linked list of cache entries, the front is the most recently used.
+type entryList[K comparable, V any] struct {
+	root cacheEntry[K, V]
+	len  int
+}
+
+func newEntryList[K comparable, V any]() *entryList[K, V] {
+	l := &entryList[K, V]{}
+	l.root.prev = &l.root
+	l.root.next = &l.root
+	return l
+}
+
+func (l *entryList[K, V]) pushFront(e *cacheEntry[K, V]) {
+	e.prev = &l.root
+	e.next = l.root.next
+	l.root.next.prev = e
+	l.root.next = e
+	l.len++
+}
+
+func (l *entryList[K, V]) remove(e *cacheEntry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	l.len--
+}
+
+func (l *entryList[K, V]) back() *cacheEntry[K, V] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+func (l *entryList[K, V]) forEach(fn func(e *cacheEntry[K, V])) {
+	for e := l.root.next; e != &l.root; e = e.next {
+		fn(e)
+	}
+}
+
+// cacheConfig holds the settings shared by LRUCache and LFUCache.
+type cacheConfig[K comparable, V any] struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	onEvict  func(key K, value V)
+	// nextExpire is zero if no entry has a TTL, otherwise it is at or before
+	// the earliest expiration time of the entries, so removeExpired only
+	// scans the entries once one of them may be expired.
+	nextExpire time.Time
+}
+
+func newCacheConfig[K comparable, V any](capacity int) cacheConfig[K, V] {
+	if capacity <= 0 {
+		panic("capacity must be greater than 0")
+	}
+	return cacheConfig[K, V]{capacity: capacity, now: time.Now}
+}
+
+func (c *cacheConfig[K, V]) expireAt() time.Time {
+	if c.ttl <= 0 {
+		return time.Time{}
+	}
+	expire := c.now().Add(c.ttl)
+	c.track(expire)
+	return expire
+}
+
+// track lowers nextExpire to expire if the entry expires earlier.
+func (c *cacheConfig[K, V]) track(expire time.Time) {
+	if !expire.IsZero() && (c.nextExpire.IsZero() || expire.Before(c.nextExpire)) {
+		c.nextExpire = expire
+	}
+}
+
+// mayExpire reports whether some entries may be expired at now.
+func (c *cacheConfig[K, V]) mayExpire(now time.Time) bool {
+	return !c.nextExpire.IsZero() && !now.Before(c.nextExpire)
+}
+
+func (c *cacheConfig[K, V]) expired(e *cacheEntry[K, V], now time.Time) bool {
+	return !e.expire.IsZero() && !now.Before(e.expire)
+}
+
+// notifier returns a function that reports evicted entries to the evict
+// callback. It must be called while holding the lock of the cache, so the
+// callback is not read concurrently with WithEvictCallback, and the returned
+// function is called after releasing the lock.
+func (c *cacheConfig[K, V]) notifier(evicted []*cacheEntry[K, V]) func() {
+	onEvict := c.onEvict
+	return func() {
+		if onEvict == nil {
+			return
+		}
+		for _, e := range evicted {
+			onEvict(e.key, e.value)
+		}
+	}
+}
+
+// LRUCache is a fixed size cache that evicts the least recently used entry
+// when it is full. Entries can optionally expire after a TTL.
+// LRUCache is safe for concurrent use.
+type LRUCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	config  cacheConfig[K, V]
+	entries map[K]*cacheEntry[K, V]
+	list    *entryList[K, V]
+}
+
+/* @example NewLRUCache
+c := gfn.NewLRUCache[string, int](2)
+c.Put("a", 1)
+c.Put("b", 2)
+c.Get("a")     // 1, true
+c.Put("c", 3)  // "b" is evicted
+c.Get("b")     // 0, false
+c.Keys()       // []string{"c", "a"}
+
+c2 := gfn.NewLRUCache[string, int](100).
+	WithTTL(time.Minute).
+	WithEvictCallback(func(k string, v int) {
+		fmt.Println("evicted", k, v)
+	})
+*/
+
+// NewLRUCache returns an empty LRU cache holding at most capacity entries.
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		config:  newCacheConfig[K, V](capacity),
+		entries: make(map[K]*cacheEntry[K, V]),
+		list:    newEntryList[K, V](),
+	}
+}
+
+// WithTTL sets the time to live of entries put after this call, zero or
+// negative ttl means entries never expire. It returns the cache itself.
+func (c *LRUCache[K, V]) WithTTL(ttl time.Duration) *LRUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.ttl = ttl
+	return c
+}
+
+// WithClock replaces time.Now as the clock used for TTL, which is mostly
+// useful in tests. It returns the cache itself.
+func (c *LRUCache[K, V]) WithClock(now func() time.Time) *LRUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.now = now
+	return c
+}
+
+// WithEvictCallback sets a function called with entries evicted because the
+// cache is full or they are expired. Entries deleted by Remove are not
+// reported. The callback is called without holding the lock of the cache.
+// It returns the cache itself.
+func (c *LRUCache[K, V]) WithEvictCallback(fn func(key K, value V)) *LRUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.onEvict = fn
+	return c
+}
+
+// Get returns the value of key and marks it as the most recently used.
+// It returns the zero value and false if key is not found or expired.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	value, ok, notify := c.get(key, true)
+	notify()
+	return value, ok
+}
+
+// Peek returns the value of key without marking it as used.
+// It returns the zero value and false if key is not found or expired.
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	value, ok, notify := c.get(key, false)
+	notify()
+	return value, ok
+}
+
+func (c *LRUCache[K, V]) get(key K, touch bool) (V, bool, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	e, ok := c.entries[key]
+	if !ok {
+		return zero, false, c.config.notifier(nil)
+	}
+	if c.config.expired(e, c.config.now()) {
+		c.remove(e)
+		return zero, false, c.config.notifier([]*cacheEntry[K, V]{e})
+	}
+	if touch {
+		c.list.remove(e)
+		c.list.pushFront(e)
+	}
+	return e.value, true, c.config.notifier(nil)
+}
+
+// Put adds or updates the value of key and marks it as the most recently
+// used. If the cache is full, expired entries are evicted first, and the
+// least recently used entry is evicted if none of them is expired.
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	var evicted []*cacheEntry[K, V]
+	if e, ok := c.entries[key]; ok {
+		e.value = value
+		e.expire = c.config.expireAt()
+		c.list.remove(e)
+		c.list.pushFront(e)
+	} else {
+		if len(c.entries) >= c.config.capacity {
+			evicted = c.removeExpired()
+		}
+		if len(c.entries) >= c.config.capacity {
+			e := c.list.back()
+			c.remove(e)
+			evicted = append(evicted, e)
+		}
+		e := &cacheEntry[K, V]{key: key, value: value, expire: c.config.expireAt()}
+		c.entries[key] = e
+		c.list.pushFront(e)
+	}
+	notify := c.config.notifier(evicted)
+	c.mu.Unlock()
+	notify()
+}
+
+// Remove deletes key from the cache. It returns false if key is not found.
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if ok {
+		c.remove(e)
+	}
+	return ok
+}
+
+func (c *LRUCache[K, V]) remove(e *cacheEntry[K, V]) {
+	c.list.remove(e)
+	delete(c.entries, e.key)
+}
+
+// removeExpired removes all expired entries and returns them in the same
+// order as Keys. It only scans the entries if one of them may be expired.
+func (c *LRUCache[K, V]) removeExpired() []*cacheEntry[K, V] {
+	now := c.config.now()
+	if !c.config.mayExpire(now) {
+		return nil
+	}
+	var evicted []*cacheEntry[K, V]
+	c.config.nextExpire = time.Time{}
+	c.list.forEach(func(e *cacheEntry[K, V]) {
+		if c.config.expired(e, now) {
+			evicted = append(evicted, e)
+		} else {
+			c.config.track(e.expire)
+		}
+	})
+	for _, e := range evicted {
+		c.remove(e)
+	}
+	return evicted
+}
+
+// Len returns the number of entries in the cache, expired entries are removed first.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	evicted := c.removeExpired()
+	res := len(c.entries)
+	notify := c.config.notifier(evicted)
+	c.mu.Unlock()
+	notify()
+	return res
+}
+
+// Keys returns the keys of the cache from the most to the least recently
+// used. Unlike Keys of a map, the order is deterministic.
+func (c *LRUCache[K, V]) Keys() []K {
+	return Map(c.Items(), func(p Pair[K, V]) K {
+		return p.First
+	})
+}
+
+// Items returns pairs of keys and values of the cache from the most to the
+// least recently used. Unlike Items of a map, the order is deterministic.
+func (c *LRUCache[K, V]) Items() []Pair[K, V] {
+	c.mu.Lock()
+	evicted := c.removeExpired()
+	res := make([]Pair[K, V], 0, len(c.entries))
+	c.list.forEach(func(e *cacheEntry[K, V]) {
+		res = append(res, Pair[K, V]{e.key, e.value})
+	})
+	notify := c.config.notifier(evicted)
+	c.mu.Unlock()
+	notify()
+	return res
+}
+
+// LFUCache is a fixed size cache that evicts the least frequently used
+// entry when it is full, ties are broken by evicting the least recently used
+// one. Entries can optionally expire after a TTL.
+// LFUCache is safe for concurrent use.
+type LFUCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	config  cacheConfig[K, V]
+	entries map[K]*cacheEntry[K, V]
+	freqs   map[int]*entryList[K, V]
+	minFreq int
+}
+
+/* @example NewLFUCache
+c := gfn.NewLFUCache[string, int](2)
+c.Put("a", 1)
+c.Put("b", 2)
+c.Get("a")     // 1, true
+c.Put("c", 3)  // "b" is evicted, it is used less than "a"
+c.Keys()       // []string{"a", "c"}
+*/
+
+// NewLFUCache returns an empty LFU cache holding at most capacity entries.
+func NewLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	return &LFUCache[K, V]{
+		config:  newCacheConfig[K, V](capacity),
+		entries: make(map[K]*cacheEntry[K, V]),
+		freqs:   make(map[int]*entryList[K, V]),
+	}
+}
+
+// WithTTL sets the time to live of entries put after this call, zero or
+// negative ttl means entries never expire. It returns the cache itself.
+func (c *LFUCache[K, V]) WithTTL(ttl time.Duration) *LFUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.ttl = ttl
+	return c
+}
+
+// WithClock replaces time.Now as the clock used for TTL, which is mostly
+// useful in tests. It returns the cache itself.
+func (c *LFUCache[K, V]) WithClock(now func() time.Time) *LFUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.now = now
+	return c
+}
+
+// WithEvictCallback sets a function called with entries evicted because the
+// cache is full or they are expired. Entries deleted by Remove are not
+// reported. The callback is called without holding the lock of the cache.
+// It returns the cache itself.
+func (c *LFUCache[K, V]) WithEvictCallback(fn func(key K, value V)) *LFUCache[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.onEvict = fn
+	return c
+}
+
+// Get returns the value of key and increases its use count.
+// It returns the zero value and false if key is not found or expired.
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	value, ok, notify := c.get(key, true)
+	notify()
+	return value, ok
+}
+
+// Peek returns the value of key without increasing its use count.
+// It returns the zero value and false if key is not found or expired.
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	value, ok, notify := c.get(key, false)
+	notify()
+	return value, ok
+}
+
+func (c *LFUCache[K, V]) get(key K, touch bool) (V, bool, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	e, ok := c.entries[key]
+	if !ok {
+		return zero, false, c.config.notifier(nil)
+	}
+	if c.config.expired(e, c.config.now()) {
+		c.remove(e)
+		return zero, false, c.config.notifier([]*cacheEntry[K, V]{e})
+	}
+	if touch {
+		c.touch(e)
+	}
+	return e.value, true, c.config.notifier(nil)
+}
+
+// Put adds or updates the value of key and increases its use count. If the
+// cache is full, expired entries are evicted first, and the least frequently
+// used entry is evicted if none of them is expired.
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	var evicted []*cacheEntry[K, V]
+	if e, ok := c.entries[key]; ok {
+		e.value = value
+		e.expire = c.config.expireAt()
+		c.touch(e)
+	} else {
+		if len(c.entries) >= c.config.capacity {
+			evicted = c.removeExpired()
+		}
+		if len(c.entries) >= c.config.capacity {
+			e := c.freqs[c.minFreq].back()
+			c.remove(e)
+			evicted = append(evicted, e)
+		}
+		e := &cacheEntry[K, V]{key: key, value: value, expire: c.config.expireAt(), freq: 1}
+		c.entries[key] = e
+		c.push(e)
+		c.minFreq = 1
+	}
+	notify := c.config.notifier(evicted)
+	c.mu.Unlock()
+	notify()
+}
+
+// Remove deletes key from the cache. It returns false if key is not found.
+func (c *LFUCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if ok {
+		c.remove(e)
+	}
+	return ok
+}
+
+func (c *LFUCache[K, V]) push(e *cacheEntry[K, V]) {
+	l, ok := c.freqs[e.freq]
+	if !ok {
+		l = newEntryList[K, V]()
+		c.freqs[e.freq] = l
+	}
+	l.pushFront(e)
+}
+
+// unlink removes e from its frequency list and reports whether the list becomes empty.
+func (c *LFUCache[K, V]) unlink(e *cacheEntry[K, V]) bool {
+	l := c.freqs[e.freq]
+	l.remove(e)
+	if l.len == 0 {
+		delete(c.freqs, e.freq)
+		return true
+	}
+	return false
+}
+
+func (c *LFUCache[K, V]) touch(e *cacheEntry[K, V]) {
+	if c.unlink(e) && c.minFreq == e.freq {
+		c.minFreq++
+	}
+	e.freq++
+	c.push(e)
+}
+
+func (c *LFUCache[K, V]) remove(e *cacheEntry[K, V]) {
+	delete(c.entries, e.key)
+	if c.unlink(e) && c.minFreq == e.freq {
+		c.minFreq = 0
+		for freq := range c.freqs {
+			if c.minFreq == 0 || freq < c.minFreq {
+				c.minFreq = freq
+			}
+		}
+	}
+}
+
+// forEach calls fn with every entry in the same order as Keys.
+func (c *LFUCache[K, V]) forEach(fn func(e *cacheEntry[K, V])) {
+	freqs := Keys(c.freqs)
+	sort.Sort(sort.Reverse(sort.IntSlice(freqs)))
+	for _, freq := range freqs {
+		c.freqs[freq].forEach(fn)
+	}
+}
+
+// removeExpired removes all expired entries and returns them in the same
+// order as Keys. It only scans the entries if one of them may be expired.
+func (c *LFUCache[K, V]) removeExpired() []*cacheEntry[K, V] {
+	now := c.config.now()
+	if !c.config.mayExpire(now) {
+		return nil
+	}
+	var evicted []*cacheEntry[K, V]
+	c.config.nextExpire = time.Time{}
+	c.forEach(func(e *cacheEntry[K, V]) {
+		if c.config.expired(e, now) {
+			evicted = append(evicted, e)
+		} else {
+			c.config.track(e.expire)
+		}
+	})
+	for _, e := range evicted {
+		c.remove(e)
+	}
+	return evicted
+}
+
+// Len returns the number of entries in the cache, expired entries are removed first.
+func (c *LFUCache[K, V]) Len() int {
+	c.mu.Lock()
+	evicted := c.removeExpired()
+	res := len(c.entries)
+	notify := c.config.notifier(evicted)
+	c.mu.Unlock()
+	notify()
+	return res
+}
+
+// Keys returns the keys of the cache from the most to the least frequently
+// used, ties are ordered from the most to the least recently used. Unlike
+// Keys of a map, the order is deterministic.
+func (c *LFUCache[K, V]) Keys() []K {
+	return Map(c.Items(), func(p Pair[K, V]) K {
+		return p.First
+	})
+}
+
+// Items returns pairs of keys and values of the cache in the same order as Keys.
+func (c *LFUCache[K, V]) Items() []Pair[K, V] {
+	c.mu.Lock()
+	evicted := c.removeExpired()
+	res := make([]Pair[K, V], 0, len(c.entries))
+	c.forEach(func(e *cacheEntry[K, V]) {
+		res = append(res, Pair[K, V]{e.key, e.value})
+	})
+	notify := c.config.notifier(evicted)
+	c.mu.Unlock()
+	notify()
+	return res
+}
@@ -0,0 +1,292 @@
+package gfn_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestNewLRUCache(t *testing.T) {
+	evicted := map[string]int{}
+	c := NewLRUCache[string, int](2).WithEvictCallback(func(k string, v int) {
+		evicted[k] = v
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	AssertEqual(t, 2, c.Len())
+
+	v, ok := c.Get("a")
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, v)
+	AssertSliceEqual(t, []string{"a", "b"}, c.Keys())
+
+	c.Put("c", 3)
+	AssertMapEqual(t, map[string]int{"b": 2}, evicted)
+	_, ok = c.Get("b")
+	AssertFalse(t, ok)
+	AssertSliceEqual(t, []string{"c", "a"}, c.Keys())
+	AssertSliceEqual(t, []Pair[string, int]{{"c", 3}, {"a", 1}}, c.Items())
+
+	// peek does not change the order
+	v, ok = c.Peek("a")
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, v)
+	_, ok = c.Peek("b")
+	AssertFalse(t, ok)
+	AssertSliceEqual(t, []string{"c", "a"}, c.Keys())
+
+	// update existing key
+	c.Put("a", 10)
+	AssertSliceEqual(t, []Pair[string, int]{{"a", 10}, {"c", 3}}, c.Items())
+	AssertEqual(t, 1, len(evicted))
+
+	// remove does not call the callback
+	AssertTrue(t, c.Remove("a"))
+	AssertFalse(t, c.Remove("a"))
+	AssertEqual(t, 1, c.Len())
+	AssertEqual(t, 1, len(evicted))
+
+	AssertPanics(t, func() {
+		NewLRUCache[string, int](0)
+	})
+
+	// concurrent use
+	cache := NewLRUCache[int, int](10)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cache.Put(i*100+j, j)
+				cache.Get(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	AssertEqual(t, 10, cache.Len())
+
+	// the evict callback can be replaced while the cache is used
+	small := NewLRUCache[int, int](1)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			small.Put(i, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			small.WithEvictCallback(func(k, v int) {})
+		}
+	}()
+	wg.Wait()
+	AssertEqual(t, 1, small.Len())
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	evicted := []string{}
+	c := NewLRUCache[string, int](10).
+		WithTTL(time.Minute).
+		WithClock(clock.Now).
+		WithEvictCallback(func(k string, v int) {
+			evicted = append(evicted, k)
+		})
+	c.Put("a", 1)
+	clock.Advance(30 * time.Second)
+	c.Put("b", 2)
+
+	v, ok := c.Get("a")
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, v)
+
+	clock.Advance(30 * time.Second)
+	_, ok = c.Get("a")
+	AssertFalse(t, ok)
+	AssertSliceEqual(t, []string{"a"}, evicted)
+	_, ok = c.Peek("b")
+	AssertTrue(t, ok)
+
+	// put resets ttl
+	c.Put("b", 3)
+	c.Put("c", 4)
+	clock.Advance(59 * time.Second)
+	AssertSliceEqual(t, []string{"c", "b"}, c.Keys())
+	c.WithTTL(0)
+	c.Put("d", 5)
+	clock.Advance(time.Second)
+	AssertEqual(t, 1, c.Len())
+	AssertSliceEqual(t, []string{"d"}, c.Keys())
+	AssertEqual(t, 3, len(evicted))
+
+	// expired entries are evicted before the least recently used one
+	evicted = []string{}
+	c = NewLRUCache[string, int](2).
+		WithClock(clock.Now).
+		WithEvictCallback(func(k string, v int) {
+			evicted = append(evicted, k)
+		})
+	c.Put("live", 1)
+	c.WithTTL(time.Second)
+	c.Put("exp", 2)
+	clock.Advance(2 * time.Second)
+	c.Put("new", 3)
+	AssertSliceEqual(t, []string{"exp"}, evicted)
+	AssertSliceEqual(t, []string{"new", "live"}, c.Keys())
+}
+
+func TestNewLFUCache(t *testing.T) {
+	evicted := map[string]int{}
+	c := NewLFUCache[string, int](2).WithEvictCallback(func(k string, v int) {
+		evicted[k] = v
+	})
+	c.Put("a", 1)
+	c.Put("b", 2)
+	AssertEqual(t, 2, c.Len())
+
+	v, ok := c.Get("a")
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, v)
+
+	c.Put("c", 3)
+	AssertMapEqual(t, map[string]int{"b": 2}, evicted)
+	AssertSliceEqual(t, []string{"a", "c"}, c.Keys())
+
+	// ties are broken by recency
+	c.Get("c")
+	AssertSliceEqual(t, []Pair[string, int]{{"c", 3}, {"a", 1}}, c.Items())
+	c.Put("d", 4)
+	AssertMapEqual(t, map[string]int{"b": 2, "a": 1}, evicted)
+	AssertSliceEqual(t, []string{"c", "d"}, c.Keys())
+
+	// peek does not increase use count
+	v, ok = c.Peek("d")
+	AssertTrue(t, ok)
+	AssertEqual(t, 4, v)
+	_, ok = c.Peek("a")
+	AssertFalse(t, ok)
+
+	// put increases use count
+	c.Put("d", 5)
+	c.Put("d", 6)
+	AssertSliceEqual(t, []Pair[string, int]{{"d", 6}, {"c", 3}}, c.Items())
+
+	AssertTrue(t, c.Remove("d"))
+	AssertFalse(t, c.Remove("d"))
+	AssertSliceEqual(t, []string{"c"}, c.Keys())
+	c.Put("e", 7)
+	c.Put("f", 8)
+	AssertSliceEqual(t, []string{"c", "f"}, c.Keys())
+
+	AssertPanics(t, func() {
+		NewLFUCache[string, int](-1)
+	})
+}
+
+func TestLFUCacheTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	evicted := []string{}
+	c := NewLFUCache[string, int](2).
+		WithTTL(time.Minute).
+		WithClock(clock.Now).
+		WithEvictCallback(func(k string, v int) {
+			evicted = append(evicted, k)
+		})
+	c.Put("a", 1)
+	c.Get("a")
+	c.Get("a")
+	clock.Advance(30 * time.Second)
+	c.Put("b", 2)
+	clock.Advance(30 * time.Second)
+
+	_, ok := c.Peek("a")
+	AssertFalse(t, ok)
+	AssertSliceEqual(t, []string{"a"}, evicted)
+	AssertEqual(t, 1, c.Len())
+
+	// minimum frequency is recalculated after expiration
+	c.Get("b")
+	c.Put("c", 3)
+	c.Put("d", 4)
+	AssertSliceEqual(t, []string{"a", "c"}, evicted)
+	AssertSliceEqual(t, []string{"b", "d"}, c.Keys())
+
+	clock.Advance(time.Minute)
+	AssertSliceEqual(t, []string{}, c.Keys())
+	AssertEqual(t, 0, c.Len())
+	AssertEqual(t, 4, len(evicted))
+
+	// expired entries are evicted before the least frequently used one
+	evicted = []string{}
+	c = NewLFUCache[string, int](2).
+		WithClock(clock.Now).
+		WithEvictCallback(func(k string, v int) {
+			evicted = append(evicted, k)
+		})
+	c.Put("live", 1)
+	c.WithTTL(time.Second)
+	c.Put("exp", 2)
+	c.Get("exp")
+	clock.Advance(2 * time.Second)
+	c.Put("new", 3)
+	AssertSliceEqual(t, []string{"exp"}, evicted)
+	AssertSliceEqual(t, []string{"new", "live"}, c.Keys())
+
+	// expired entries are reported in the same order as keys
+	evicted = []string{}
+	c = NewLFUCache[string, int](10).
+		WithTTL(time.Second).
+		WithClock(clock.Now).
+		WithEvictCallback(func(k string, v int) {
+			evicted = append(evicted, k)
+		})
+	for i, k := range []string{"a", "b", "c", "d", "e", "f"} {
+		c.Put(k, i)
+		for j := 0; j < i%3; j++ {
+			c.Get(k)
+		}
+	}
+	keys := c.Keys()
+	AssertSliceEqual(t, []string{"f", "c", "e", "b", "d", "a"}, keys)
+	clock.Advance(time.Second)
+	AssertEqual(t, 0, c.Len())
+	AssertSliceEqual(t, keys, evicted)
+}
+
+func BenchmarkLRUCachePutTTL(b *testing.B) {
+	c := NewLRUCache[int, int](100000).WithTTL(time.Hour)
+	for i := 0; i < 100000; i++ {
+		c.Put(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Put(100000+i, i)
+	}
+}
+
+func BenchmarkLFUCachePutTTL(b *testing.B) {
+	c := NewLFUCache[int, int](100000).WithTTL(time.Hour)
+	for i := 0; i < 100000; i++ {
+		c.Put(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Put(100000+i, i)
+	}
+}
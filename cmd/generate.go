@@ -15,8 +15,19 @@ import (
 var categories = [][2]string{
 	{"Functional", "fp.go"},
 	{"Math", "math.go"},
+	{"Statistics", "stat.go"},
 	{"Array", "array.go"},
+	{"Grid", "grid.go"},
+	{"Diff", "diff.go"},
+	{"Combinatorics", "combinatorics.go"},
+	{"Join", "join.go"},
 	{"Map", "map.go"},
+	{"Heap", "heap.go"},
+	{"Collection", "collection.go"},
+	{"Cache", "cache.go"},
+	{"Bitset", "bitset.go"},
+	{"Multiset", "multiset.go"},
+	{"Decimal", "decimal.go"},
 }
 
 const readmeTemplateFile = "README.tmpl.md"
@@ -129,10 +140,11 @@ func (f *function) Title() string {
 }
 
 func (f *function) TOC() string {
+	name := strings.ReplaceAll(f.Name, ".", "")
 	if f.deprecated {
-		return f.Name + "-deprecated"
+		return name + "-deprecated"
 	}
-	return f.Name
+	return name
 }
 
 func (f *function) addComment(line string) {
@@ -159,16 +171,39 @@ func (f *function) addComment(line string) {
 
 func (f *function) addSignature(line string) {
 	line = strings.TrimSpace(line)
-	nameFirstChar := string(strings.TrimPrefix(line, "func ")[0])
-	if nameFirstChar != strings.ToUpper(nameFirstChar) {
+	name := strings.TrimPrefix(line, "func ")
+	receiver := ""
+	if strings.HasPrefix(name, "(") {
+		// method, e.g. func (h *Heap[T]) Push(value T)
+		end := strings.Index(name, ")")
+		if end < 0 {
+			f.state = stateAbort
+			return
+		}
+		fields := strings.Fields(name[1:end])
+		receiver = strings.TrimPrefix(fields[len(fields)-1], "*")
+		if i := strings.Index(receiver, "["); i >= 0 {
+			receiver = receiver[:i]
+		}
+		name = strings.TrimSpace(name[end+1:])
+	}
+	if !isExported(name) || (receiver != "" && !isExported(receiver)) {
 		f.state = stateAbort
 		return
 	}
+	if receiver != "" {
+		f.Name = receiver + "." + f.Name
+	}
 	line = strings.TrimRight(line, "{")
 	f.Signature = line
 	f.state = stateFinish
 }
 
+func isExported(name string) bool {
+	first := string(name[0])
+	return first == strings.ToUpper(first)
+}
+
 func (f *function) finish() bool {
 	return f.state == stateFinish
 }
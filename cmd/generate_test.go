@@ -33,6 +33,18 @@ this is multiline comments for F2.
 func F2(a int) int {
 	return a
 }
+
+// Push is a method.
+func (s *Stack[T]) Push(value T) {
+}
+
+// pop is a method that should be skipped.
+func (s *Stack[T]) pop() T {
+}
+
+// Len is a method of unexported type that should be skipped.
+func (s stack) Len() int {
+}
 `
 	dir, err := os.MkdirTemp("", "test-generate")
 	if err != nil {
@@ -53,6 +65,7 @@ func F2(a int) int {
 	toc := `- [Test](#test)
   - [gfn.F1](#gfnf1)
   - [gfn.F2 (Deprecated)](#gfnf2-deprecated)
+  - [gfn.Stack.Push](#gfnstackpush)
 `
 	if cat.toc() != toc {
 		t.Fatalf("toc not match, expect: %s, got: %s", toc, cat.toc())
@@ -85,6 +98,14 @@ Deprecated: F2 is f2.
 this is multiline comments for F2.
 ;;;
 [back to top](#gfn)
+
+
+### gfn.Stack.Push
+;;;go
+func (s *Stack[T]) Push(value T) 
+;;;
+Push is a method.
+
 `
 	expected := strings.TrimSpace(strings.ReplaceAll(content, ";;;", "```"))
 	got := strings.TrimSpace(cat.content())
@@ -0,0 +1,459 @@
+package gfn
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrFull is returned when pushing to a full bounded collection whose
+// overflow policy is OverflowError.
+var ErrFull = errors.New("collection is full")
+
+// OverflowPolicy decides what a bounded collection does when a value is
+// pushed and the collection is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the push until another goroutine makes room.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the element at the opposite end of the push to make room.
+	OverflowDropOldest
+	// OverflowError rejects the push and returns ErrFull.
+	OverflowError
+)
+
+// minDequeSize is the smallest non-empty size of the ring buffer of a Deque.
+const minDequeSize = 8
+
+// Deque is a double-ended queue backed by a growable ring buffer. Pushing
+// and popping at both ends are amortized O(1), and the buffer shrinks when
+// most of it is unused. The zero value is an empty unbounded deque ready to
+// use. A Deque is safe for concurrent use.
+type Deque[T any] struct {
+	mu sync.Mutex
+	// notFull is used through cond, which sets its lock on first use so the
+	// zero value of Deque works.
+	notFull  sync.Cond
+	buf      []T
+	head     int
+	size     int
+	capacity int
+	policy   OverflowPolicy
+}
+
+/* @example NewDeque
+d := gfn.NewDeque(1, 2, 3)
+d.PushFront(0)
+d.PushBack(4)
+d.PopFront()  // 0, true
+d.PopBack()   // 4, true
+d.ToSlice()   // []int{1, 2, 3}
+*/
+
+// NewDeque returns an unbounded deque initialized with given values from front to back.
+func NewDeque[T any](values ...T) *Deque[T] {
+	d := &Deque[T]{}
+	d.init(values)
+	return d
+}
+
+/* @example NewBoundedDeque
+d := gfn.NewBoundedDeque[int](2, gfn.OverflowDropOldest)
+d.PushBack(1)
+d.PushBack(2)
+d.PushBack(3)  // 1 is dropped
+d.ToSlice()    // []int{2, 3}
+d.PushFront(0) // 3 is dropped
+d.ToSlice()    // []int{0, 2}
+
+d2 := gfn.NewBoundedDeque[int](1, gfn.OverflowError)
+d2.PushBack(1)  // nil
+d2.PushBack(2)  // gfn.ErrFull
+*/
+
+// NewBoundedDeque returns an empty deque that holds at most capacity elements.
+// The policy decides what happens when a value is pushed to a full deque.
+func NewBoundedDeque[T any](capacity int, policy OverflowPolicy) *Deque[T] {
+	d := &Deque[T]{}
+	d.initBounded(capacity, policy)
+	return d
+}
+
+// init sets the initial values of a new deque.
+func (d *Deque[T]) init(values []T) {
+	if len(values) > 0 {
+		d.buf = make([]T, Max(len(values), minDequeSize))
+		copy(d.buf, values)
+		d.size = len(values)
+	}
+}
+
+// initBounded sets the capacity and overflow policy of a new deque.
+func (d *Deque[T]) initBounded(capacity int, policy OverflowPolicy) {
+	if capacity <= 0 {
+		panic("capacity must be greater than 0")
+	}
+	if policy < OverflowBlock || policy > OverflowError {
+		panic("invalid overflow policy")
+	}
+	d.capacity = capacity
+	d.policy = policy
+}
+
+// cond returns the condition variable signaled when an element is removed,
+// it must be called while holding the lock.
+func (d *Deque[T]) cond() *sync.Cond {
+	if d.notFull.L == nil {
+		d.notFull.L = &d.mu
+	}
+	return &d.notFull
+}
+
+// Len returns the number of elements in the deque.
+func (d *Deque[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.size
+}
+
+// Cap returns the maximum number of elements of the deque, 0 means unbounded.
+func (d *Deque[T]) Cap() int {
+	return d.capacity
+}
+
+// PushBack adds a value to the back of the deque. For a full bounded deque,
+// it follows the overflow policy and may block or return ErrFull.
+func (d *Deque[T]) PushBack(value T) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.makeRoom(false); err != nil {
+		return err
+	}
+	d.buf[d.index(d.size)] = value
+	d.size++
+	return nil
+}
+
+// PushFront adds a value to the front of the deque. For a full bounded deque,
+// it follows the overflow policy and may block or return ErrFull.
+func (d *Deque[T]) PushFront(value T) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.makeRoom(true); err != nil {
+		return err
+	}
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = value
+	d.size++
+	return nil
+}
+
+// PopFront removes and returns the front element of the deque. It returns
+// the zero value and false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.popFront(), true
+}
+
+// PopBack removes and returns the back element of the deque. It returns
+// the zero value and false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.popBack(), true
+}
+
+// Front returns the front element of the deque without removing it. It
+// returns the zero value and false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element of the deque without removing it. It
+// returns the zero value and false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.size-1)], true
+}
+
+// At returns the i-th element from the front of the deque. At panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i < 0 || i >= d.size {
+		panic("index out of range")
+	}
+	return d.buf[d.index(i)]
+}
+
+// Clear removes all elements from the deque and releases its buffer.
+func (d *Deque[T]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.buf = nil
+	d.head = 0
+	d.size = 0
+	d.cond().Broadcast()
+}
+
+// ToSlice returns a new array containing the elements of the deque from front to back.
+func (d *Deque[T]) ToSlice() []T {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.toSlice()
+}
+
+func (d *Deque[T]) toSlice() []T {
+	res := make([]T, d.size)
+	if d.size == 0 {
+		return res
+	}
+	n := copy(res, d.buf[d.head:Min(d.head+d.size, len(d.buf))])
+	copy(res[n:], d.buf[:d.size-n])
+	return res
+}
+
+// index returns the buffer index of the i-th element from the front.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// makeRoom makes sure there is room for one more element, applying the
+// overflow policy when the deque is full. front tells which end is pushed.
+func (d *Deque[T]) makeRoom(front bool) error {
+	if d.capacity > 0 && d.size >= d.capacity {
+		switch d.policy {
+		case OverflowError:
+			return ErrFull
+		case OverflowDropOldest:
+			if front {
+				d.popBack()
+			} else {
+				d.popFront()
+			}
+		default:
+			for d.size >= d.capacity {
+				d.cond().Wait()
+			}
+		}
+	}
+	if d.size == len(d.buf) {
+		size := Max(2*len(d.buf), minDequeSize)
+		if d.capacity > 0 {
+			size = Min(size, d.capacity)
+		}
+		d.resize(size)
+	}
+	return nil
+}
+
+func (d *Deque[T]) popFront() T {
+	var zero T
+	res := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	d.afterPop()
+	return res
+}
+
+func (d *Deque[T]) popBack() T {
+	var zero T
+	i := d.index(d.size - 1)
+	res := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	d.afterPop()
+	return res
+}
+
+// afterPop shrinks the buffer when it is at most a quarter full and wakes
+// up a goroutine waiting to push.
+func (d *Deque[T]) afterPop() {
+	if len(d.buf) > minDequeSize && d.size <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+	d.cond().Signal()
+}
+
+func (d *Deque[T]) resize(size int) {
+	buf := make([]T, size)
+	if d.size > 0 {
+		copy(buf, d.toSlice())
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+// Queue is a first-in-first-out collection backed by a Deque. The zero
+// value is an empty unbounded queue ready to use. A Queue is safe for
+// concurrent use.
+type Queue[T any] struct {
+	deque Deque[T]
+}
+
+/* @example NewQueue
+q := gfn.NewQueue(1, 2)
+q.Push(3)
+q.Pop()      // 1, true
+q.Peek()     // 2, true
+q.ToSlice()  // []int{2, 3}
+
+// interoperate with other functions
+gfn.Map(q.ToSlice(), func(i int) int { return i * 10 }) // []int{20, 30}
+*/
+
+// NewQueue returns an unbounded queue initialized with given values, the
+// first value is popped first.
+func NewQueue[T any](values ...T) *Queue[T] {
+	q := &Queue[T]{}
+	q.deque.init(values)
+	return q
+}
+
+/* @example NewBoundedQueue
+q := gfn.NewBoundedQueue[int](2, gfn.OverflowDropOldest)
+q.Push(1)
+q.Push(2)
+q.Push(3)
+q.ToSlice()  // []int{2, 3}
+*/
+
+// NewBoundedQueue returns an empty queue that holds at most capacity elements.
+// The policy decides what happens when a value is pushed to a full queue.
+func NewBoundedQueue[T any](capacity int, policy OverflowPolicy) *Queue[T] {
+	q := &Queue[T]{}
+	q.deque.initBounded(capacity, policy)
+	return q
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	return q.deque.Len()
+}
+
+// Push adds a value to the back of the queue. For a full bounded queue,
+// it follows the overflow policy and may block or return ErrFull.
+func (q *Queue[T]) Push(value T) error {
+	return q.deque.PushBack(value)
+}
+
+// Pop removes and returns the front element of the queue. It returns the
+// zero value and false if the queue is empty.
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.deque.PopFront()
+}
+
+// Peek returns the front element of the queue without removing it. It
+// returns the zero value and false if the queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.deque.Front()
+}
+
+// Clear removes all elements from the queue.
+func (q *Queue[T]) Clear() {
+	q.deque.Clear()
+}
+
+// ToSlice returns a new array containing the elements of the queue in pop order.
+func (q *Queue[T]) ToSlice() []T {
+	return q.deque.ToSlice()
+}
+
+// Stack is a last-in-first-out collection backed by a Deque. The zero
+// value is an empty unbounded stack ready to use. A Stack is safe for
+// concurrent use.
+type Stack[T any] struct {
+	deque Deque[T]
+}
+
+/* @example NewStack
+s := gfn.NewStack(1, 2)
+s.Push(3)
+s.Pop()      // 3, true
+s.Peek()     // 2, true
+s.ToSlice()  // []int{1, 2}
+
+// pop order
+array := s.ToSlice()
+gfn.Reverse(array)  // []int{2, 1}
+*/
+
+// NewStack returns an unbounded stack initialized with given values, the
+// last value is on the top.
+func NewStack[T any](values ...T) *Stack[T] {
+	s := &Stack[T]{}
+	s.deque.init(values)
+	return s
+}
+
+/* @example NewBoundedStack
+s := gfn.NewBoundedStack[int](2, gfn.OverflowDropOldest)
+s.Push(1)
+s.Push(2)
+s.Push(3)    // 1 at the bottom is dropped
+s.ToSlice()  // []int{2, 3}
+*/
+
+// NewBoundedStack returns an empty stack that holds at most capacity elements.
+// The policy decides what happens when a value is pushed to a full stack,
+// OverflowDropOldest drops the bottom element.
+func NewBoundedStack[T any](capacity int, policy OverflowPolicy) *Stack[T] {
+	s := &Stack[T]{}
+	s.deque.initBounded(capacity, policy)
+	return s
+}
+
+// Len returns the number of elements in the stack.
+func (s *Stack[T]) Len() int {
+	return s.deque.Len()
+}
+
+// Push adds a value to the top of the stack. For a full bounded stack,
+// it follows the overflow policy and may block or return ErrFull.
+func (s *Stack[T]) Push(value T) error {
+	return s.deque.PushBack(value)
+}
+
+// Pop removes and returns the top element of the stack. It returns the
+// zero value and false if the stack is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	return s.deque.PopBack()
+}
+
+// Peek returns the top element of the stack without removing it. It
+// returns the zero value and false if the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	return s.deque.Back()
+}
+
+// Clear removes all elements from the stack.
+func (s *Stack[T]) Clear() {
+	s.deque.Clear()
+}
+
+// ToSlice returns a new array containing the elements of the stack from
+// bottom to top. Use Reverse on the result to get the pop order.
+func (s *Stack[T]) ToSlice() []T {
+	return s.deque.ToSlice()
+}
@@ -0,0 +1,306 @@
+package gfn_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func TestNewDeque(t *testing.T) {
+	// zero value is ready to use
+	var zero Deque[int]
+	AssertTrue(t, zero.PushBack(1) == nil)
+	AssertTrue(t, zero.PushFront(0) == nil)
+	v, ok := zero.PopFront()
+	AssertTrue(t, ok)
+	AssertEqual(t, 0, v)
+	v, ok = zero.PopBack()
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, v)
+	_, ok = zero.PopBack()
+	AssertFalse(t, ok)
+	for i := 0; i < 100; i++ {
+		AssertTrue(t, zero.PushFront(i) == nil)
+	}
+	zero.Clear()
+	AssertEqual(t, 0, zero.Len())
+
+	d := NewDeque(1, 2, 3)
+	AssertEqual(t, 3, d.Len())
+	AssertEqual(t, 0, d.Cap())
+	AssertTrue(t, d.PushFront(0) == nil)
+	AssertTrue(t, d.PushBack(4) == nil)
+	AssertSliceEqual(t, []int{0, 1, 2, 3, 4}, d.ToSlice())
+	AssertEqual(t, 2, d.At(2))
+	AssertPanics(t, func() {
+		d.At(5)
+	})
+
+	v, ok = d.Front()
+	AssertTrue(t, ok)
+	AssertEqual(t, 0, v)
+	v, ok = d.Back()
+	AssertTrue(t, ok)
+	AssertEqual(t, 4, v)
+
+	v, ok = d.PopFront()
+	AssertTrue(t, ok)
+	AssertEqual(t, 0, v)
+	v, ok = d.PopBack()
+	AssertTrue(t, ok)
+	AssertEqual(t, 4, v)
+	AssertSliceEqual(t, []int{1, 2, 3}, d.ToSlice())
+
+	d.Clear()
+	AssertEqual(t, 0, d.Len())
+	AssertSliceEqual(t, []int{}, d.ToSlice())
+	_, ok = d.PopFront()
+	AssertFalse(t, ok)
+	_, ok = d.PopBack()
+	AssertFalse(t, ok)
+	_, ok = d.Front()
+	AssertFalse(t, ok)
+	_, ok = d.Back()
+	AssertFalse(t, ok)
+
+	// compare with slice
+	for i := 0; i < 100; i++ {
+		d := NewDeque[int]()
+		expected := []int{}
+		for j := 0; j < 1000; j++ {
+			switch rand.Intn(5) {
+			case 0:
+				_ = d.PushFront(j)
+				expected = append([]int{j}, expected...)
+			case 1, 2:
+				_ = d.PushBack(j)
+				expected = append(expected, j)
+			case 3:
+				v, ok := d.PopFront()
+				AssertEqual(t, len(expected) > 0, ok)
+				if ok {
+					AssertEqual(t, expected[0], v)
+					expected = expected[1:]
+				}
+			case 4:
+				v, ok := d.PopBack()
+				AssertEqual(t, len(expected) > 0, ok)
+				if ok {
+					AssertEqual(t, expected[len(expected)-1], v)
+					expected = expected[:len(expected)-1]
+				}
+			}
+		}
+		AssertSliceEqual(t, expected, d.ToSlice())
+		AssertEqual(t, len(expected), d.Len())
+	}
+}
+
+func TestNewBoundedDeque(t *testing.T) {
+	d := NewBoundedDeque[int](2, OverflowDropOldest)
+	AssertEqual(t, 2, d.Cap())
+	AssertTrue(t, d.PushBack(1) == nil)
+	AssertTrue(t, d.PushBack(2) == nil)
+	AssertTrue(t, d.PushBack(3) == nil)
+	AssertSliceEqual(t, []int{2, 3}, d.ToSlice())
+	AssertTrue(t, d.PushFront(0) == nil)
+	AssertSliceEqual(t, []int{0, 2}, d.ToSlice())
+
+	d = NewBoundedDeque[int](1, OverflowError)
+	AssertTrue(t, d.PushBack(1) == nil)
+	AssertTrue(t, d.PushBack(2) == ErrFull)
+	AssertTrue(t, d.PushFront(2) == ErrFull)
+	AssertSliceEqual(t, []int{1}, d.ToSlice())
+
+	d = NewBoundedDeque[int](2, OverflowBlock)
+	AssertTrue(t, d.PushBack(1) == nil)
+	AssertTrue(t, d.PushBack(2) == nil)
+	done := make(chan struct{})
+	go func() {
+		_ = d.PushBack(3)
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("push should block when deque is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+	v, _ := d.PopFront()
+	AssertEqual(t, 1, v)
+	<-done
+	AssertSliceEqual(t, []int{2, 3}, d.ToSlice())
+
+	AssertPanics(t, func() {
+		NewBoundedDeque[int](0, OverflowBlock)
+	})
+	AssertPanics(t, func() {
+		NewBoundedDeque[int](1, OverflowPolicy(10))
+	})
+}
+
+func TestNewQueue(t *testing.T) {
+	var zero Queue[int]
+	AssertTrue(t, zero.Push(1) == nil)
+	AssertTrue(t, zero.Push(2) == nil)
+	v, ok := zero.Pop()
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, v)
+	AssertSliceEqual(t, []int{2}, zero.ToSlice())
+
+	q := NewQueue(1, 2)
+	AssertTrue(t, q.Push(3) == nil)
+	AssertEqual(t, 3, q.Len())
+	v, ok = q.Pop()
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, v)
+	v, ok = q.Peek()
+	AssertTrue(t, ok)
+	AssertEqual(t, 2, v)
+	AssertSliceEqual(t, []int{2, 3}, q.ToSlice())
+	AssertSliceEqual(t, []int{20, 30}, Map(q.ToSlice(), func(i int) int { return i * 10 }))
+
+	q.Clear()
+	AssertEqual(t, 0, q.Len())
+	_, ok = q.Pop()
+	AssertFalse(t, ok)
+	_, ok = q.Peek()
+	AssertFalse(t, ok)
+
+	// producers and consumers
+	q = NewBoundedQueue[int](4, OverflowBlock)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = q.Push(i*100 + j)
+			}
+		}(i)
+	}
+	seen := map[int]struct{}{}
+	for len(seen) < 400 {
+		if v, ok := q.Pop(); ok {
+			seen[v] = struct{}{}
+		}
+	}
+	wg.Wait()
+	AssertEqual(t, 0, q.Len())
+}
+
+func TestNewBoundedQueue(t *testing.T) {
+	q := NewBoundedQueue[int](2, OverflowDropOldest)
+	_ = q.Push(1)
+	_ = q.Push(2)
+	_ = q.Push(3)
+	AssertSliceEqual(t, []int{2, 3}, q.ToSlice())
+
+	q = NewBoundedQueue[int](2, OverflowError)
+	AssertTrue(t, q.Push(1) == nil)
+	AssertTrue(t, q.Push(2) == nil)
+	AssertTrue(t, q.Push(3) == ErrFull)
+	q.Pop()
+	AssertTrue(t, q.Push(3) == nil)
+	AssertSliceEqual(t, []int{2, 3}, q.ToSlice())
+}
+
+func TestNewStack(t *testing.T) {
+	var zero Stack[int]
+	AssertTrue(t, zero.Push(1) == nil)
+	AssertTrue(t, zero.Push(2) == nil)
+	v, ok := zero.Pop()
+	AssertTrue(t, ok)
+	AssertEqual(t, 2, v)
+	AssertSliceEqual(t, []int{1}, zero.ToSlice())
+
+	s := NewStack(1, 2)
+	AssertTrue(t, s.Push(3) == nil)
+	AssertEqual(t, 3, s.Len())
+	v, ok = s.Pop()
+	AssertTrue(t, ok)
+	AssertEqual(t, 3, v)
+	v, ok = s.Peek()
+	AssertTrue(t, ok)
+	AssertEqual(t, 2, v)
+	AssertSliceEqual(t, []int{1, 2}, s.ToSlice())
+
+	array := s.ToSlice()
+	Reverse(array)
+	AssertSliceEqual(t, []int{2, 1}, array)
+
+	s.Clear()
+	AssertEqual(t, 0, s.Len())
+	_, ok = s.Pop()
+	AssertFalse(t, ok)
+	_, ok = s.Peek()
+	AssertFalse(t, ok)
+}
+
+func TestNewBoundedStack(t *testing.T) {
+	s := NewBoundedStack[int](2, OverflowDropOldest)
+	_ = s.Push(1)
+	_ = s.Push(2)
+	_ = s.Push(3)
+	AssertSliceEqual(t, []int{2, 3}, s.ToSlice())
+	v, _ := s.Pop()
+	AssertEqual(t, 3, v)
+
+	s = NewBoundedStack[int](1, OverflowError)
+	AssertTrue(t, s.Push(1) == nil)
+	AssertTrue(t, s.Push(2) == ErrFull)
+}
+
+func BenchmarkQueue(b *testing.B) {
+	q := NewQueue[int]()
+	for i := 0; i < b.N; i++ {
+		_ = q.Push(i)
+		if i%2 == 1 {
+			q.Pop()
+		}
+	}
+}
+
+func BenchmarkSliceQueue(b *testing.B) {
+	q := []int{}
+	for i := 0; i < b.N; i++ {
+		q = append(q, i)
+		if i%2 == 1 {
+			q = q[1:]
+		}
+	}
+}
+
+func BenchmarkStack(b *testing.B) {
+	s := NewStack[int]()
+	for i := 0; i < b.N; i++ {
+		_ = s.Push(i)
+		if i%2 == 1 {
+			s.Pop()
+		}
+	}
+}
+
+func BenchmarkDeque(b *testing.B) {
+	d := NewDeque[int]()
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			_ = d.PushFront(i)
+		} else {
+			_ = d.PushBack(i)
+		}
+		if i%3 == 2 {
+			d.PopFront()
+			d.PopBack()
+		}
+	}
+}
+
+func BenchmarkBoundedQueue(b *testing.B) {
+	q := NewBoundedQueue[int](1024, OverflowDropOldest)
+	for i := 0; i < b.N; i++ {
+		_ = q.Push(i)
+	}
+}
@@ -0,0 +1,366 @@
+package gfn
+
+/* @example CartesianProduct
+gfn.CartesianProduct([]int{1, 2}, []int{3, 4})
+// [][]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}
+*/
+
+// CartesianProduct returns every combination that takes one value from each
+// array, in lexicographic order of positions, the last array changes the
+// fastest. It returns an empty array if any array is empty.
+func CartesianProduct[T any](arrays ...[]T) [][]T {
+	res := [][]T{}
+	ForEachCartesianProduct(func(values []T) bool {
+		res = append(res, Copy(values))
+		return true
+	}, arrays...)
+	return res
+}
+
+/* @example ForEachCartesianProduct
+gfn.ForEachCartesianProduct(func(values []string) bool {
+	fmt.Println(values)
+	return true
+}, []string{"linux", "darwin"}, []string{"amd64", "arm64"})
+// [linux amd64]
+// [linux arm64]
+// [darwin amd64]
+// [darwin arm64]
+*/
+
+// ForEachCartesianProduct calls fn with every combination in the same order
+// as CartesianProduct, without keeping them in memory. It stops if fn
+// returns false. The slice passed to fn is reused between calls, copy it to
+// keep it.
+func ForEachCartesianProduct[T any](fn func([]T) bool, arrays ...[]T) {
+	if len(arrays) < 2 {
+		panic("requires at least 2 arrays")
+	}
+	for _, array := range arrays {
+		if len(array) == 0 {
+			return
+		}
+	}
+
+	indexes := make([]int, len(arrays))
+	values := Map(arrays, func(array []T) T { return array[0] })
+	for {
+		if !fn(values) {
+			return
+		}
+		// increase the indexes like an odometer
+		i := len(arrays) - 1
+		for ; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(arrays[i]) {
+				values[i] = arrays[i][indexes[i]]
+				break
+			}
+			indexes[i] = 0
+			values[i] = arrays[i][0]
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+/* @example Permutations
+gfn.Permutations([]int{1, 2, 3}, 2)
+// [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}
+*/
+
+// Permutations returns every ordered arrangement of k values of the array, in
+// lexicographic order of positions. Values at different positions are
+// treated as distinct even if they are equal. It returns an empty array if k
+// is larger than the length of the array, and panics if k is negative.
+func Permutations[T any](array []T, k int) [][]T {
+	res := [][]T{}
+	ForEachPermutation(array, k, func(values []T) bool {
+		res = append(res, Copy(values))
+		return true
+	})
+	return res
+}
+
+/* @example ForEachPermutation
+gfn.ForEachPermutation([]string{"a", "b", "c"}, 3, func(values []string) bool {
+	fmt.Println(values)
+	return values[0] == "a"
+})
+// [a b c]
+// [a c b]
+// [b a c]
+*/
+
+// ForEachPermutation calls fn with every permutation in the same order as
+// Permutations, without keeping them in memory. It stops if fn returns
+// false. The slice passed to fn is reused between calls, copy it to keep it.
+func ForEachPermutation[T any](array []T, k int, fn func([]T) bool) {
+	checkK(k)
+	if k > len(array) {
+		return
+	}
+
+	values := make([]T, k)
+	used := make([]bool, len(array))
+	var permute func(depth int) bool
+	permute = func(depth int) bool {
+		if depth == k {
+			return fn(values)
+		}
+		for i, v := range array {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			values[depth] = v
+			ok := permute(depth + 1)
+			used[i] = false
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	permute(0)
+}
+
+/* @example Combinations
+gfn.Combinations([]int{1, 2, 3, 4}, 2)
+// [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}
+*/
+
+// Combinations returns every selection of k values of the array that keeps
+// their order in the array, in lexicographic order of positions. Values at
+// different positions are treated as distinct even if they are equal. It
+// returns an empty array if k is larger than the length of the array, and
+// panics if k is negative.
+func Combinations[T any](array []T, k int) [][]T {
+	res := [][]T{}
+	ForEachCombination(array, k, func(values []T) bool {
+		res = append(res, Copy(values))
+		return true
+	})
+	return res
+}
+
+/* @example ForEachCombination
+count := 0
+gfn.ForEachCombination(gfn.Range(0, 50), 6, func(values []int) bool {
+	count++
+	return true
+})
+// count: 15890700
+*/
+
+// ForEachCombination calls fn with every combination in the same order as
+// Combinations, without keeping them in memory. It stops if fn returns
+// false. The slice passed to fn is reused between calls, copy it to keep it.
+func ForEachCombination[T any](array []T, k int, fn func([]T) bool) {
+	checkK(k)
+	forEachCombination(array, k, false, fn)
+}
+
+/* @example CombinationsWithReplacement
+gfn.CombinationsWithReplacement([]string{"a", "b"}, 2)
+// [][]string{{"a", "a"}, {"a", "b"}, {"b", "b"}}
+*/
+
+// CombinationsWithReplacement returns every selection of k values of the
+// array like Combinations, but a value may be selected more than once. It
+// returns an empty array if the array is empty and k is positive, and
+// panics if k is negative.
+func CombinationsWithReplacement[T any](array []T, k int) [][]T {
+	res := [][]T{}
+	ForEachCombinationWithReplacement(array, k, func(values []T) bool {
+		res = append(res, Copy(values))
+		return true
+	})
+	return res
+}
+
+/* @example ForEachCombinationWithReplacement
+gfn.ForEachCombinationWithReplacement([]int{1, 2, 3}, 2, func(values []int) bool {
+	fmt.Println(values)
+	return values[1] != 3
+})
+// [1 1]
+// [1 2]
+// [1 3]
+*/
+
+// ForEachCombinationWithReplacement calls fn with every combination in the
+// same order as CombinationsWithReplacement, without keeping them in memory.
+// It stops if fn returns false. The slice passed to fn is reused between
+// calls, copy it to keep it.
+func ForEachCombinationWithReplacement[T any](array []T, k int, fn func([]T) bool) {
+	checkK(k)
+	forEachCombination(array, k, true, fn)
+}
+
+// forEachCombination generates the combinations of k values by their
+// indexes, which are ascending, or non-decreasing if replacement is true.
+func forEachCombination[T any](array []T, k int, replacement bool, fn func([]T) bool) {
+	n := len(array)
+	if (k > n && !replacement) || (n == 0 && k > 0) {
+		return
+	}
+
+	indexes := make([]int, k)
+	if !replacement {
+		for i := range indexes {
+			indexes[i] = i
+		}
+	}
+	// maxIndex returns the largest possible index at position i
+	maxIndex := func(i int) int {
+		if replacement {
+			return n - 1
+		}
+		return n - k + i
+	}
+
+	values := make([]T, k)
+	for {
+		for i, idx := range indexes {
+			values[i] = array[idx]
+		}
+		if !fn(values) {
+			return
+		}
+
+		// find the rightmost index that can still increase
+		i := k - 1
+		for i >= 0 && indexes[i] == maxIndex(i) {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			if replacement {
+				indexes[j] = indexes[i]
+			} else {
+				indexes[j] = indexes[j-1] + 1
+			}
+		}
+	}
+}
+
+/* @example PowerSet
+gfn.PowerSet([]int{1, 2, 3})
+// [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}
+*/
+
+// PowerSet returns every subset of the array, ordered by size and then like
+// Combinations. The array has 2^n subsets, please use ForEachSubset for
+// large arrays.
+func PowerSet[T any](array []T) [][]T {
+	res := [][]T{}
+	ForEachSubset(array, func(values []T) bool {
+		res = append(res, Copy(values))
+		return true
+	})
+	return res
+}
+
+/* @example ForEachSubset
+gfn.ForEachSubset([]int{1, 2, 3}, func(values []int) bool {
+	fmt.Println(values)
+	return len(values) < 2
+})
+// []
+// [1]
+// [2]
+// [3]
+// [1 2]
+*/
+
+// ForEachSubset calls fn with every subset in the same order as PowerSet,
+// without keeping them in memory. It stops if fn returns false. The slice
+// passed to fn is reused between calls, copy it to keep it.
+func ForEachSubset[T any](array []T, fn func([]T) bool) {
+	stopped := false
+	for k := 0; k <= len(array) && !stopped; k++ {
+		ForEachCombination(array, k, func(values []T) bool {
+			stopped = !fn(values)
+			return !stopped
+		})
+	}
+}
+
+func checkK(k int) {
+	if k < 0 {
+		panic("k must not be negative")
+	}
+}
+
+/* @example NCr
+gfn.NCr(5, 2)         // 10, true
+gfn.NCr(100, 50)      // 0, false, overflows int
+gfn.NCr[uint8](10, 3) // 120, true
+*/
+
+// NCr returns the number of ways to choose k values out of n without order
+// and true, or false if the result overflows T. It returns 0 if k is larger
+// than n, and panics if n or k is negative.
+func NCr[T Int | Uint](n, k T) (T, bool) {
+	if n < 0 || k < 0 {
+		panic("n and k must not be negative")
+	}
+	if k > n {
+		return 0, true
+	}
+	if k > n-k {
+		k = n - k
+	}
+
+	var res T = 1
+	for i := T(0); i < k; i++ {
+		// res * (n-i) is divisible by i+1, divide before multiplying to
+		// avoid overflowing intermediate results
+		g := gcd(res, i+1)
+		factor, ok := MulChecked(res/g, (n-i)/((i+1)/g))
+		if !ok {
+			return 0, false
+		}
+		res = factor
+	}
+	return res, true
+}
+
+/* @example NPr
+gfn.NPr(5, 2)      // 20, true
+gfn.NPr(30, 20)    // 0, false, overflows int
+*/
+
+// NPr returns the number of ordered arrangements of k values out of n and
+// true, or false if the result overflows T. It returns 0 if k is larger than
+// n, and panics if n or k is negative.
+func NPr[T Int | Uint](n, k T) (T, bool) {
+	if n < 0 || k < 0 {
+		panic("n and k must not be negative")
+	}
+	if k > n {
+		return 0, true
+	}
+
+	var res T = 1
+	for i := T(0); i < k; i++ {
+		product, ok := MulChecked(res, n-i)
+		if !ok {
+			return 0, false
+		}
+		res = product
+	}
+	return res, true
+}
+
+func gcd[T Int | Uint](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,207 @@
+package gfn_test
+
+import (
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func TestCartesianProduct(t *testing.T) {
+	assertGridEqual(t, [][]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}, CartesianProduct([]int{1, 2}, []int{3, 4}))
+	assertGridEqual(t, [][]string{{"a", "x", "1"}, {"a", "y", "1"}}, CartesianProduct([]string{"a"}, []string{"x", "y"}, []string{"1"}))
+	assertGridEqual(t, [][]int{}, CartesianProduct([]int{1, 2}, []int{}))
+	AssertEqual(t, 24, len(CartesianProduct([]int{1, 2}, []int{1, 2, 3}, []int{1, 2, 3, 4})))
+
+	AssertPanics(t, func() {
+		CartesianProduct([]int{1, 2})
+	})
+}
+
+func TestForEachCartesianProduct(t *testing.T) {
+	res := [][]string{}
+	ForEachCartesianProduct(func(values []string) bool {
+		res = append(res, Copy(values))
+		return len(res) < 3
+	}, []string{"linux", "darwin"}, []string{"amd64", "arm64"})
+	assertGridEqual(t, [][]string{{"linux", "amd64"}, {"linux", "arm64"}, {"darwin", "amd64"}}, res)
+
+	AssertPanics(t, func() {
+		ForEachCartesianProduct(func(values []int) bool {
+			return true
+		})
+	})
+}
+
+func TestPermutations(t *testing.T) {
+	assertGridEqual(t, [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}, Permutations([]int{1, 2, 3}, 2))
+	assertGridEqual(t, [][]int{{}}, Permutations([]int{1, 2, 3}, 0))
+	assertGridEqual(t, [][]int{}, Permutations([]int{1, 2, 3}, 4))
+	assertGridEqual(t, [][]int{{1, 1}, {1, 1}}, Permutations([]int{1, 1}, 2))
+	AssertEqual(t, 120, len(Permutations(Range(0, 5), 5)))
+	AssertEqual(t, 60, len(Uniq(Map(Permutations([]rune("abcde"), 3), func(r []rune) string {
+		return string(r)
+	}))))
+
+	AssertPanics(t, func() {
+		Permutations([]int{1}, -1)
+	})
+}
+
+func TestForEachPermutation(t *testing.T) {
+	res := [][]string{}
+	ForEachPermutation([]string{"a", "b", "c"}, 3, func(values []string) bool {
+		res = append(res, Copy(values))
+		return values[0] == "a"
+	})
+	assertGridEqual(t, [][]string{{"a", "b", "c"}, {"a", "c", "b"}, {"b", "a", "c"}}, res)
+}
+
+func TestCombinations(t *testing.T) {
+	assertGridEqual(t, [][]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}}, Combinations([]int{1, 2, 3, 4}, 2))
+	assertGridEqual(t, [][]int{{}}, Combinations([]int{}, 0))
+	assertGridEqual(t, [][]int{{1, 2, 3}}, Combinations([]int{1, 2, 3}, 3))
+	assertGridEqual(t, [][]int{}, Combinations([]int{1, 2, 3}, 4))
+
+	for n := 0; n < 8; n++ {
+		for k := 0; k <= n; k++ {
+			expected, _ := NCr(n, k)
+			AssertEqual(t, expected, len(Combinations(Range(0, n), k)))
+		}
+	}
+
+	AssertPanics(t, func() {
+		Combinations([]int{1}, -1)
+	})
+}
+
+func TestForEachCombination(t *testing.T) {
+	count := 0
+	ForEachCombination(Range(0, 20), 6, func(values []int) bool {
+		AssertEqual(t, 6, len(values))
+		AssertTrue(t, IsSorted(values))
+		count++
+		return true
+	})
+	AssertEqual(t, 38760, count)
+
+	count = 0
+	ForEachCombination(Range(0, 20), 6, func(values []int) bool {
+		count++
+		return count < 10
+	})
+	AssertEqual(t, 10, count)
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	assertGridEqual(t, [][]string{{"a", "a"}, {"a", "b"}, {"b", "b"}}, CombinationsWithReplacement([]string{"a", "b"}, 2))
+	assertGridEqual(t, [][]int{{1, 1, 1}}, CombinationsWithReplacement([]int{1}, 3))
+	assertGridEqual(t, [][]int{{}}, CombinationsWithReplacement([]int{1, 2}, 0))
+	assertGridEqual(t, [][]int{{}}, CombinationsWithReplacement([]int{}, 0))
+	assertGridEqual(t, [][]int{}, CombinationsWithReplacement([]int{}, 1))
+
+	// C(n+k-1, k)
+	expected, _ := NCr(4+3-1, 3)
+	AssertEqual(t, expected, len(CombinationsWithReplacement(Range(0, 4), 3)))
+
+	AssertPanics(t, func() {
+		CombinationsWithReplacement([]int{1}, -1)
+	})
+}
+
+func TestForEachCombinationWithReplacement(t *testing.T) {
+	res := [][]int{}
+	ForEachCombinationWithReplacement([]int{1, 2, 3}, 2, func(values []int) bool {
+		res = append(res, Copy(values))
+		return values[1] != 3
+	})
+	assertGridEqual(t, [][]int{{1, 1}, {1, 2}, {1, 3}}, res)
+}
+
+func TestPowerSet(t *testing.T) {
+	assertGridEqual(t, [][]int{{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}, PowerSet([]int{1, 2, 3}))
+	assertGridEqual(t, [][]int{{}}, PowerSet([]int{}))
+	AssertEqual(t, 1024, len(PowerSet(Range(0, 10))))
+}
+
+func TestForEachSubset(t *testing.T) {
+	res := [][]int{}
+	ForEachSubset([]int{1, 2, 3}, func(values []int) bool {
+		res = append(res, Copy(values))
+		return len(values) < 2
+	})
+	assertGridEqual(t, [][]int{{}, {1}, {2}, {3}, {1, 2}}, res)
+}
+
+func TestNCr(t *testing.T) {
+	assertNCr := func(expected int, n, k int) {
+		t.Helper()
+		res, ok := NCr(n, k)
+		AssertTrue(t, ok)
+		AssertEqual(t, expected, res)
+	}
+	assertNCr(10, 5, 2)
+	assertNCr(1, 5, 0)
+	assertNCr(1, 5, 5)
+	assertNCr(0, 5, 6)
+	assertNCr(1, 0, 0)
+	assertNCr(118264581564861424, 60, 30)
+
+	res, ok := NCr[uint8](10, 3)
+	AssertTrue(t, ok)
+	AssertEqual(t, uint8(120), res)
+	_, ok = NCr[uint8](255, 2)
+	AssertFalse(t, ok)
+	_, ok = NCr(100, 50)
+	AssertFalse(t, ok)
+	res64, ok := NCr[int64](66, 33)
+	AssertTrue(t, ok)
+	AssertEqual(t, int64(7219428434016265740), res64)
+	_, ok = NCr[int64](68, 34)
+	AssertFalse(t, ok)
+
+	// Pascal's rule
+	for n := 1; n < 30; n++ {
+		for k := 1; k < n; k++ {
+			a, _ := NCr(n-1, k-1)
+			b, _ := NCr(n-1, k)
+			c, _ := NCr(n, k)
+			AssertEqual(t, a+b, c)
+		}
+	}
+
+	AssertPanics(t, func() {
+		NCr(-1, 1)
+	})
+	AssertPanics(t, func() {
+		NCr(1, -1)
+	})
+}
+
+func TestNPr(t *testing.T) {
+	res, ok := NPr(5, 2)
+	AssertTrue(t, ok)
+	AssertEqual(t, 20, res)
+	res, ok = NPr(5, 0)
+	AssertTrue(t, ok)
+	AssertEqual(t, 1, res)
+	res, ok = NPr(5, 6)
+	AssertTrue(t, ok)
+	AssertEqual(t, 0, res)
+	res, ok = NPr(20, 20)
+	AssertTrue(t, ok)
+	AssertEqual(t, 2432902008176640000, res)
+
+	_, ok = NPr(30, 20)
+	AssertFalse(t, ok)
+	_, ok = NPr[uint64](21, 21)
+	AssertFalse(t, ok)
+	u, ok := NPr[uint8](5, 3)
+	AssertTrue(t, ok)
+	AssertEqual(t, uint8(60), u)
+	_, ok = NPr[int8](6, 4)
+	AssertFalse(t, ok)
+
+	AssertPanics(t, func() {
+		NPr(-1, 1)
+	})
+}
@@ -0,0 +1,318 @@
+package gfn
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalScale is the largest number of fractional digits of a Decimal,
+// 10^18 is the largest power of 10 that fits in an int64.
+const maxDecimalScale = 18
+
+var pow10 = func() [maxDecimalScale + 1]int64 {
+	var res [maxDecimalScale + 1]int64
+	res[0] = 1
+	for i := 1; i < len(res); i++ {
+		res[i] = res[i-1] * 10
+	}
+	return res
+}()
+
+// Decimal is a fixed-point decimal number, stored as an int64 number of
+// units and a scale, which is the number of fractional digits. Its value is
+// units * 10^-scale, so 12.34 is 1234 units with scale 2. Decimal implements
+// Adder, Averager and Comparer, so it works with SumOf, MeanOf, MaxOf and
+// MinOf. Operations panic if the result overflows int64 units. The zero
+// value is 0. Decimals with the same value but different scales, such as
+// 1.5 and 1.50, are not equal with ==, use Cmp to compare values.
+type Decimal struct {
+	units int64
+	scale int
+}
+
+/* @example NewDecimal
+gfn.NewDecimal(1234, 2)  // 12.34
+gfn.NewDecimal(-5, 3)    // -0.005
+gfn.NewDecimal(7, 0)     // 7
+*/
+
+// NewDecimal returns the decimal units * 10^-scale. The scale must be between 0 and 18.
+func NewDecimal(units int64, scale int) Decimal {
+	if scale < 0 || scale > maxDecimalScale {
+		panic("scale must be between 0 and 18")
+	}
+	return Decimal{units: units, scale: scale}
+}
+
+/* @example ParseDecimal
+d, err := gfn.ParseDecimal("-12.340")
+// d: -12.340, err: nil
+_, err = gfn.ParseDecimal("1e3")
+// err: invalid decimal "1e3"
+*/
+
+// ParseDecimal parses a decimal written as an optional sign, digits and an
+// optional fractional part, like "12", "-0.5" or "+3.140". The scale of the
+// result is the number of digits after the point, trailing zeros included.
+// It returns an error if the string is not a decimal, it has more than 18
+// fractional digits or its units overflow int64.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+	if intPart+fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if len(fracPart) > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q has more than %d fractional digits", s, maxDecimalScale)
+	}
+
+	units, err := strconv.ParseInt(s[:len(s)-len(digits)]+intPart+fracPart, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("decimal %q overflows", s)
+	}
+	return Decimal{units: units, scale: len(fracPart)}, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Units returns the units of the decimal, the value is Units() * 10^-Scale().
+func (d Decimal) Units() int64 {
+	return d.units
+}
+
+// Scale returns the number of fractional digits of the decimal.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+/* @example Decimal.Add
+a := gfn.NewDecimal(150, 2)  // 1.50
+b := gfn.NewDecimal(25, 1)   // 2.5
+a.Add(b)                     // 4.00
+a.Sub(b)                     // -1.00
+a.Mul(b)                     // 3.750
+a.DivInt(4)                  // 0.38
+a.Cmp(b)                     // -1
+*/
+
+// Add returns d + other, the scale of the result is the larger scale.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := alignDecimals(d, other)
+	units, ok := AddChecked(a.units, b.units)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return Decimal{units: units, scale: a.scale}
+}
+
+// Sub returns d - other, the scale of the result is the larger scale.
+func (d Decimal) Sub(other Decimal) Decimal {
+	return d.Add(other.Neg())
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	units, ok := MulChecked(d.units, -1)
+	if !ok {
+		panic("decimal overflow")
+	}
+	return Decimal{units: units, scale: d.scale}
+}
+
+// Mul returns d * other, the scale of the result is the sum of both scales.
+// If the sum is larger than 18, the result is rounded to 18 fractional digits.
+func (d Decimal) Mul(other Decimal) Decimal {
+	scale := d.scale + other.scale
+	if scale <= maxDecimalScale {
+		units, ok := MulChecked(d.units, other.units)
+		if !ok {
+			panic("decimal overflow")
+		}
+		return Decimal{units: units, scale: scale}
+	}
+	return Decimal{units: mulDivRound(d.units, other.units, pow10[scale-maxDecimalScale]), scale: maxDecimalScale}
+}
+
+// DivInt returns d / n with the scale of d, rounded half away from zero. It
+// panics if n is zero.
+func (d Decimal) DivInt(n int) Decimal {
+	if n == 0 {
+		panic("division by zero")
+	}
+	return Decimal{units: divRound(d.units, int64(n)), scale: d.scale}
+}
+
+// Round returns d rounded half away from zero to the given number of
+// fractional digits, which must be between 0 and 18.
+func (d Decimal) Round(scale int) Decimal {
+	if scale < 0 || scale > maxDecimalScale {
+		panic("scale must be between 0 and 18")
+	}
+	if scale >= d.scale {
+		res, ok := d.rescale(scale)
+		if !ok {
+			panic("decimal overflow")
+		}
+		return res
+	}
+	return Decimal{units: divRound(d.units, pow10[d.scale-scale]), scale: scale}
+}
+
+// Cmp returns -1, 0 or +1 if d is less than, equal to or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := d, other
+	if a.scale < b.scale {
+		scaled, ok := a.rescale(b.scale)
+		if !ok {
+			// |a| is larger than any value of b's scale
+			return a.Sign()
+		}
+		a = scaled
+	} else if b.scale < a.scale {
+		scaled, ok := b.rescale(a.scale)
+		if !ok {
+			return -b.Sign()
+		}
+		b = scaled
+	}
+
+	switch {
+	case a.units < b.units:
+		return -1
+	case a.units > b.units:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Sign returns -1, 0 or +1 if d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	return float64(d.units) / math.Pow10(d.scale)
+}
+
+// String returns d with exactly Scale() fractional digits, like "-12.340".
+func (d Decimal) String() string {
+	sign := ""
+	abs := uint64(d.units)
+	if d.units < 0 {
+		sign = "-"
+		abs = -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// rescale returns d with a larger scale and false if the units overflow.
+func (d Decimal) rescale(scale int) (Decimal, bool) {
+	units, ok := MulChecked(d.units, pow10[scale-d.scale])
+	return Decimal{units: units, scale: scale}, ok
+}
+
+// alignDecimals returns both decimals with the larger scale of them.
+func alignDecimals(a, b Decimal) (Decimal, Decimal) {
+	var ok bool
+	if a.scale < b.scale {
+		a, ok = a.rescale(b.scale)
+	} else {
+		b, ok = b.rescale(a.scale)
+	}
+	if !ok {
+		panic("decimal overflow")
+	}
+	return a, b
+}
+
+// mulDivRound returns a * b / c rounded half away from zero for positive c.
+// The product is computed in 128 bits, so it only panics if the result
+// overflows int64.
+func mulDivRound(a, b, c int64) int64 {
+	negative := (a < 0) != (b < 0)
+	absA, absB := uint64(a), uint64(b)
+	if a < 0 {
+		absA = -absA
+	}
+	if b < 0 {
+		absB = -absB
+	}
+	hi, lo := bits.Mul64(absA, absB)
+	divisor := uint64(c)
+	if hi >= divisor {
+		panic("decimal overflow")
+	}
+	q, r := bits.Div64(hi, lo, divisor)
+	// the magnitude of the minimum int64 is one more than the maximum, and
+	// checking before rounding up keeps q++ from wrapping around
+	if q > math.MaxInt64+1 {
+		panic("decimal overflow")
+	}
+	if r >= divisor-r {
+		q++
+	}
+	if q > math.MaxInt64 && !(negative && q == math.MaxInt64+1) {
+		panic("decimal overflow")
+	}
+	if negative {
+		return int64(-q)
+	}
+	return int64(q)
+}
+
+// divRound returns a / b rounded half away from zero.
+func divRound(a, b int64) int64 {
+	q, r, ok := DivModChecked(a, b)
+	if !ok {
+		panic("decimal overflow")
+	}
+	// compare |r| with |b| - |r| to avoid overflowing 2 * |r|
+	absR, absB := uint64(r), uint64(b)
+	if r < 0 {
+		absR = -absR
+	}
+	if b < 0 {
+		absB = -absB
+	}
+	if absR >= absB-absR {
+		if (a < 0) != (b < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
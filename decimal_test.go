@@ -0,0 +1,152 @@
+package gfn_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func mustParseDecimal(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", s, err)
+	}
+	return d
+}
+
+func TestNewDecimal(t *testing.T) {
+	AssertEqual(t, "12.34", NewDecimal(1234, 2).String())
+	AssertEqual(t, "-0.005", NewDecimal(-5, 3).String())
+	AssertEqual(t, "7", NewDecimal(7, 0).String())
+	AssertEqual(t, "0", Decimal{}.String())
+	AssertEqual(t, "-9.223372036854775808", NewDecimal(math.MinInt64, 18).String())
+	AssertEqual(t, int64(1234), NewDecimal(1234, 2).Units())
+	AssertEqual(t, 2, NewDecimal(1234, 2).Scale())
+
+	AssertPanics(t, func() {
+		NewDecimal(1, -1)
+	})
+	AssertPanics(t, func() {
+		NewDecimal(1, 19)
+	})
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, s := range []string{"12", "-0.5", "3.140", "0.000", "-12.340", "9223372036854775807", "0.000000000000000001"} {
+		d := mustParseDecimal(t, s)
+		AssertEqual(t, s, d.String(), s)
+	}
+	AssertEqual(t, "3.140", mustParseDecimal(t, "+3.140").String())
+	AssertEqual(t, "0.5", mustParseDecimal(t, ".5").String())
+	AssertEqual(t, "1", mustParseDecimal(t, "1.").String())
+
+	for _, s := range []string{"", "-", ".", "1e3", "1.2.3", "--1", "+-1", " 1", "0.1234567890123456789", "9223372036854775808"} {
+		_, err := ParseDecimal(s)
+		AssertTrue(t, err != nil, s)
+	}
+}
+
+func TestDecimalAdd(t *testing.T) {
+	a, b := NewDecimal(150, 2), NewDecimal(25, 1)
+	AssertEqual(t, "4.00", a.Add(b).String())
+	AssertEqual(t, "4.00", b.Add(a).String())
+	AssertEqual(t, "-1.00", a.Sub(b).String())
+	AssertEqual(t, "-1.50", a.Neg().String())
+
+	AssertPanics(t, func() {
+		NewDecimal(math.MaxInt64, 0).Add(NewDecimal(1, 0))
+	})
+	AssertPanics(t, func() {
+		NewDecimal(math.MaxInt64, 0).Add(NewDecimal(1, 1))
+	})
+	AssertPanics(t, func() {
+		NewDecimal(math.MinInt64, 0).Neg()
+	})
+}
+
+func TestDecimalMul(t *testing.T) {
+	AssertEqual(t, "3.750", NewDecimal(150, 2).Mul(NewDecimal(25, 1)).String())
+	AssertEqual(t, "-6", NewDecimal(-2, 0).Mul(NewDecimal(3, 0)).String())
+	// 0.0000000005 * 0.0000000003 = 1.5e-19, rounded to 18 fractional digits
+	AssertEqual(t, "0.000000000000000000", NewDecimal(5, 10).Mul(NewDecimal(3, 10)).String())
+	AssertEqual(t, "0.000000000000000001", NewDecimal(25, 10).Mul(NewDecimal(2, 10)).String())
+	AssertEqual(t, "-0.000000000000000002", NewDecimal(-15, 10).Mul(NewDecimal(1, 9)).String())
+
+	// the product of the units overflows int64, but the rounded result fits
+	d := NewDecimal(1e10, 18).Mul(NewDecimal(1e10, 18))
+	AssertEqual(t, int64(100), d.Units())
+	AssertEqual(t, 18, d.Scale())
+	AssertEqual(t, int64(-100), NewDecimal(-1e10, 18).Mul(NewDecimal(1e10, 18)).Units())
+	AssertEqual(t, int64(math.MaxInt64), NewDecimal(math.MaxInt64, 18).Mul(NewDecimal(10, 1)).Units())
+	AssertEqual(t, int64(math.MinInt64), NewDecimal(math.MinInt64, 18).Mul(NewDecimal(10, 1)).Units())
+	AssertEqual(t, int64(-math.MaxInt64), NewDecimal(math.MaxInt64, 18).Mul(NewDecimal(-10, 1)).Units())
+
+	AssertPanics(t, func() {
+		NewDecimal(math.MaxInt64, 0).Mul(NewDecimal(2, 0))
+	})
+	AssertPanics(t, func() {
+		NewDecimal(math.MaxInt64, 18).Mul(NewDecimal(20, 1))
+	})
+	AssertPanics(t, func() {
+		NewDecimal(math.MinInt64, 18).Mul(NewDecimal(-10, 1))
+	})
+	AssertPanics(t, func() {
+		NewDecimal(math.MaxInt64, 18).Mul(NewDecimal(math.MaxInt64, 1))
+	})
+}
+
+func TestDecimalDivInt(t *testing.T) {
+	AssertEqual(t, "0.38", NewDecimal(150, 2).DivInt(4).String())
+	AssertEqual(t, "-0.38", NewDecimal(-150, 2).DivInt(4).String())
+	AssertEqual(t, "-0.38", NewDecimal(150, 2).DivInt(-4).String())
+	AssertEqual(t, "0.33", NewDecimal(100, 2).DivInt(3).String())
+	AssertEqual(t, "3", NewDecimal(9, 0).DivInt(3).String())
+	AssertEqual(t, "4611686018427387904", NewDecimal(math.MaxInt64, 0).DivInt(2).String())
+
+	AssertPanics(t, func() {
+		NewDecimal(1, 0).DivInt(0)
+	})
+	AssertPanics(t, func() {
+		NewDecimal(math.MinInt64, 0).DivInt(-1)
+	})
+}
+
+func TestDecimalRound(t *testing.T) {
+	AssertEqual(t, "1.24", NewDecimal(12350, 4).Round(2).String())
+	AssertEqual(t, "1.23", NewDecimal(12349, 4).Round(2).String())
+	AssertEqual(t, "-2", NewDecimal(-15, 1).Round(0).String())
+	AssertEqual(t, "1.5000", NewDecimal(15, 1).Round(4).String())
+
+	AssertPanics(t, func() {
+		NewDecimal(1, 0).Round(19)
+	})
+	AssertPanics(t, func() {
+		NewDecimal(math.MaxInt64, 0).Round(1)
+	})
+}
+
+func TestDecimalCmp(t *testing.T) {
+	AssertEqual(t, -1, NewDecimal(150, 2).Cmp(NewDecimal(25, 1)))
+	AssertEqual(t, 1, NewDecimal(25, 1).Cmp(NewDecimal(150, 2)))
+	AssertEqual(t, 0, NewDecimal(15, 1).Cmp(NewDecimal(150, 2)))
+	AssertEqual(t, 0, Decimal{}.Cmp(NewDecimal(0, 5)))
+	// rescaling overflows
+	AssertEqual(t, 1, NewDecimal(math.MaxInt64, 0).Cmp(NewDecimal(1, 18)))
+	AssertEqual(t, -1, NewDecimal(math.MinInt64, 0).Cmp(NewDecimal(1, 18)))
+	AssertEqual(t, -1, NewDecimal(1, 18).Cmp(NewDecimal(math.MaxInt64, 0)))
+	AssertEqual(t, 1, NewDecimal(-1, 18).Cmp(NewDecimal(math.MinInt64, 0)))
+}
+
+func TestDecimalSign(t *testing.T) {
+	AssertEqual(t, -1, NewDecimal(-1, 2).Sign())
+	AssertEqual(t, 0, Decimal{}.Sign())
+	AssertEqual(t, 1, NewDecimal(1, 2).Sign())
+}
+
+func TestDecimalFloat64(t *testing.T) {
+	AssertFloatEqual(t, 12.34, NewDecimal(1234, 2).Float64())
+	AssertFloatEqual(t, -0.005, NewDecimal(-5, 3).Float64())
+	AssertFloatEqual(t, 0, Decimal{}.Float64())
+}
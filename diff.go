@@ -0,0 +1,516 @@
+package gfn
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EditOp is the operation of an Edit.
+type EditOp int
+
+const (
+	// EditEqual keeps a value that is in both arrays.
+	EditEqual EditOp = iota
+	// EditDelete removes a value of the first array.
+	EditDelete
+	// EditInsert adds a value of the second array.
+	EditInsert
+)
+
+// Edit is one step of an edit script. Value is the value of the second array
+// for EditEqual and EditInsert, and the value of the first array for EditDelete.
+type Edit[T any] struct {
+	Op    EditOp
+	Value T
+}
+
+/* @example EditScript
+gfn.EditScript([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+// []gfn.Edit[string]{
+// 	{gfn.EditEqual, "a"},
+// 	{gfn.EditDelete, "b"},
+// 	{gfn.EditEqual, "c"},
+// 	{gfn.EditInsert, "d"},
+// }
+*/
+
+// EditScript returns the shortest edit script that turns a into b, using
+// Myers' algorithm. It takes O((n+m)d) time and O(n+m) memory, where d is
+// the number of deleted and inserted values. When a value is replaced, its deletion comes
+// before its insertion.
+func EditScript[T comparable](a, b []T) []Edit[T] {
+	return EditScriptBy(a, b, func(v T) T { return v })
+}
+
+/* @example EditScriptBy
+type Route struct {
+	prefix  string
+	nextHop string
+}
+a := []Route{{"10.0.0.0/8", "r1"}, {"192.168.0.0/16", "r2"}}
+b := []Route{{"10.0.0.0/8", "r3"}, {"172.16.0.0/12", "r2"}}
+gfn.EditScriptBy(a, b, func(r Route) string {
+	return r.prefix
+})
+// []gfn.Edit[Route]{
+// 	{gfn.EditEqual, Route{"10.0.0.0/8", "r3"}},
+// 	{gfn.EditDelete, Route{"192.168.0.0/16", "r2"}},
+// 	{gfn.EditInsert, Route{"172.16.0.0/12", "r2"}},
+// }
+*/
+
+// EditScriptBy returns the shortest edit script that turns a into b like
+// EditScript, values are equal if their keys returned by fn are equal.
+func EditScriptBy[T any, U comparable](a, b []T, fn func(T) U) []Edit[T] {
+	d := &myersDiff[T, U]{a: a, b: b, keysA: Map(a, fn), keysB: Map(b, fn), res: []Edit[T]{}}
+	d.diff(0, len(a), 0, len(b))
+
+	// within each run of changes, move the deletions before the insertions
+	for i := 0; i < len(d.res); {
+		if d.res[i].Op == EditEqual {
+			i++
+			continue
+		}
+		j := i
+		for j < len(d.res) && d.res[j].Op != EditEqual {
+			j++
+		}
+		sort.SliceStable(d.res[i:j], func(x, y int) bool {
+			return d.res[i+x].Op == EditDelete && d.res[i+y].Op == EditInsert
+		})
+		i = j
+	}
+	return d.res
+}
+
+// myersDiff finds the shortest edit script with the linear space variant of
+// Myers' algorithm, which splits the arrays at the middle of a shortest path
+// and solves both halves recursively, so it needs O(n+m) memory.
+type myersDiff[T any, U comparable] struct {
+	a, b         []T
+	keysA, keysB []U
+	res          []Edit[T]
+}
+
+// diff appends the edits turning a[aLo:aHi] into b[bLo:bHi].
+func (d *myersDiff[T, U]) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.keysA[aLo] == d.keysB[bLo] {
+		d.res = append(d.res, Edit[T]{EditEqual, d.b[bLo]})
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi && bLo < bHi && d.keysA[aHi-1] == d.keysB[bHi-1] {
+		aHi--
+		bHi--
+		suffix++
+	}
+
+	if aLo == aHi || bLo == bHi {
+		d.change(aLo, aHi, bLo, bHi)
+	} else if x, y, ok := d.middle(aLo, aHi, bLo, bHi); ok {
+		d.diff(aLo, x, bLo, y)
+		d.diff(x, aHi, y, bHi)
+	} else {
+		d.change(aLo, aHi, bLo, bHi)
+	}
+
+	for i := bHi; i < bHi+suffix; i++ {
+		d.res = append(d.res, Edit[T]{EditEqual, d.b[i]})
+	}
+}
+
+// change appends the deletion of a[aLo:aHi] and the insertion of b[bLo:bHi].
+func (d *myersDiff[T, U]) change(aLo, aHi, bLo, bHi int) {
+	for _, v := range d.a[aLo:aHi] {
+		d.res = append(d.res, Edit[T]{EditDelete, v})
+	}
+	for _, v := range d.b[bLo:bHi] {
+		d.res = append(d.res, Edit[T]{EditInsert, v})
+	}
+}
+
+// middle searches a shortest path from both ends of non-empty a[aLo:aHi] and
+// b[bLo:bHi] at the same time, and returns the point where the paths meet.
+// It returns false if the arrays have no value in common.
+func (d *myersDiff[T, U]) middle(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	// vf[offset+k] is the furthest x reached on diagonal k = x - y from the
+	// start, vb[offset+k] is the same from the end, -1 means not reached
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+	for i := range vf {
+		vf[i] = -1
+		vb[i] = -1
+	}
+	vf[offset+1] = 0
+	vb[offset+1] = 0
+
+	delta := n - m
+	// the paths meet on a forward step if delta is odd, else on a backward step
+	front := delta%2 != 0
+	// diagonals that leave the arrays are skipped in later steps
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+	for step := 0; step < maxD; step++ {
+		for k := -step + fStart; k <= step-fEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -step || (k != step && vf[i-1] < vf[i+1]) {
+				x = vf[i+1]
+			} else {
+				x = vf[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.keysA[aLo+x] == d.keysB[bLo+y] {
+				x++
+				y++
+			}
+			vf[i] = x
+			if x > n {
+				fEnd += 2
+			} else if y > m {
+				fStart += 2
+			} else if front {
+				j := offset + delta - k
+				if j >= 0 && j < len(vb) && vb[j] != -1 && x >= n-vb[j] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+
+		for k := -step + bStart; k <= step-bEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -step || (k != step && vb[i-1] < vb[i+1]) {
+				x = vb[i+1]
+			} else {
+				x = vb[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.keysA[aHi-x-1] == d.keysB[bHi-y-1] {
+				x++
+				y++
+			}
+			vb[i] = x
+			if x > n {
+				bEnd += 2
+			} else if y > m {
+				bStart += 2
+			} else if !front {
+				j := offset + delta - k
+				if j >= 0 && j < len(vf) && vf[j] != -1 && vf[j] >= n-x {
+					fx := vf[j]
+					return aLo + fx, bLo + fx - (j - offset), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+/* @example Patch
+a := []int{1, 2, 3}
+b := []int{1, 3, 4}
+gfn.Patch(a, gfn.EditScript(a, b))  // []int{1, 3, 4}
+*/
+
+// Patch applies an edit script to a and returns the result, so that
+// Patch(a, EditScript(a, b)) equals b. Every EditEqual and EditDelete
+// consumes the next value of a, it panics if the script does not consume
+// all values of a exactly. The values of a are not compared with the script.
+func Patch[T any](a []T, script []Edit[T]) []T {
+	res := make([]T, 0, len(a))
+	i := 0
+	for _, edit := range script {
+		switch edit.Op {
+		case EditEqual, EditDelete:
+			if i >= len(a) {
+				panic("script does not match array")
+			}
+			i++
+			if edit.Op == EditEqual {
+				res = append(res, edit.Value)
+			}
+		case EditInsert:
+			res = append(res, edit.Value)
+		default:
+			panic("invalid edit op")
+		}
+	}
+	if i != len(a) {
+		panic("script does not match array")
+	}
+	return res
+}
+
+/* @example RenderUnifiedDiff
+a := []string{"a", "b", "c", "d", "e", "f"}
+b := []string{"a", "c", "d", "e", "x", "f"}
+fmt.Print(gfn.RenderUnifiedDiff(gfn.EditScript(a, b), 1, func(s string) string {
+	return s
+}))
+// @@ -1,3 +1,2 @@
+//  a
+// -b
+//  c
+// @@ -5,2 +4,3 @@
+//  e
+// +x
+//  f
+*/
+
+// RenderUnifiedDiff renders an edit script as text in the unified diff
+// format, without file headers. Every value is formatted to one line by
+// format and prefixed by " ", "-" or "+". Changes are grouped in hunks with
+// up to context unchanged lines around them. It returns an empty string if
+// there is no change.
+func RenderUnifiedDiff[T any](script []Edit[T], context int, format func(T) string) string {
+	if context < 0 {
+		panic("context must not be negative")
+	}
+
+	// line numbers of a and b before every edit
+	lineA := make([]int, len(script)+1)
+	lineB := make([]int, len(script)+1)
+	for i, edit := range script {
+		lineA[i+1], lineB[i+1] = lineA[i], lineB[i]
+		if edit.Op != EditInsert {
+			lineA[i+1]++
+		}
+		if edit.Op != EditDelete {
+			lineB[i+1]++
+		}
+	}
+
+	sb := strings.Builder{}
+	for i := 0; i < len(script); i++ {
+		if script[i].Op == EditEqual {
+			continue
+		}
+
+		// extend the hunk while the gap to the next change fits in the context of both
+		start := Max(0, i-context)
+		end := i + 1
+		for j := end; j < len(script) && j-end <= 2*context; j++ {
+			if script[j].Op != EditEqual {
+				end = j + 1
+			}
+		}
+		end = Min(len(script), end+context)
+
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+			hunkRange(lineA[start], lineA[end]-lineA[start]),
+			hunkRange(lineB[start], lineB[end]-lineB[start])))
+		for _, edit := range script[start:end] {
+			prefix := " "
+			if edit.Op == EditDelete {
+				prefix = "-"
+			} else if edit.Op == EditInsert {
+				prefix = "+"
+			}
+			sb.WriteString(prefix + format(edit.Value) + "\n")
+		}
+		i = end - 1
+	}
+	return sb.String()
+}
+
+// hunkRange formats the range of a hunk, where start is the number of lines
+// before the hunk.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+/* @example Levenshtein
+gfn.Levenshtein([]int{1, 2, 3}, []int{1, 3, 4})  // 2
+*/
+
+// Levenshtein returns the Levenshtein distance of two arrays, which is the
+// minimum number of insertions, deletions and substitutions of single values
+// that turn a into b. It takes O(nm) time and O(min(n, m)) space.
+func Levenshtein[T comparable](a, b []T) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+
+	// prev[j] is the distance between the current prefix of a and b[:j]
+	prev := Range(0, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = Min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+/* @example LevenshteinString
+gfn.LevenshteinString("kitten", "sitting")  // 3
+gfn.LevenshteinString("café", "cafe")       // 1
+*/
+
+// LevenshteinString returns the Levenshtein distance of two strings, counted by runes.
+func LevenshteinString(a, b string) int {
+	return Levenshtein([]rune(a), []rune(b))
+}
+
+/* @example DamerauLevenshtein
+gfn.DamerauLevenshtein([]int{1, 2, 3}, []int{2, 1, 3})  // 1
+*/
+
+// DamerauLevenshtein returns the Damerau-Levenshtein distance of two arrays,
+// which also counts the transposition of two adjacent values as a single
+// edit. This is the unrestricted distance, a transposed pair may be edited
+// again, so the distance of "ca" and "abc" is 2. It takes O(nm) time and space.
+func DamerauLevenshtein[T comparable](a, b []T) int {
+	n, m := len(a), len(b)
+	maxDist := n + m
+	// d[i+1][j+1] is the distance between a[:i] and b[:j], row and column 0
+	// hold maxDist as sentinels for transpositions
+	d := make([][]int, n+2)
+	for i := range d {
+		d[i] = make([]int, m+2)
+	}
+	d[0][0] = maxDist
+	for i := 0; i <= n; i++ {
+		d[i+1][0] = maxDist
+		d[i+1][1] = i
+	}
+	for j := 0; j <= m; j++ {
+		d[0][j+1] = maxDist
+		d[1][j+1] = j
+	}
+
+	// lastRow[v] is the last row of a where v appears
+	lastRow := make(map[T]int)
+	for i := 1; i <= n; i++ {
+		// the last column of b in this row that matches a[i-1]
+		lastMatchCol := 0
+		for j := 1; j <= m; j++ {
+			k := lastRow[b[j-1]]
+			l := lastMatchCol
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+				lastMatchCol = j
+			}
+			d[i+1][j+1] = Min(
+				d[i][j]+cost,
+				d[i+1][j]+1,
+				d[i][j+1]+1,
+				d[k][l]+(i-k-1)+1+(j-l-1),
+			)
+		}
+		lastRow[a[i-1]] = i
+	}
+	return d[n+1][m+1]
+}
+
+/* @example DamerauLevenshteinString
+gfn.DamerauLevenshteinString("ca", "abc")      // 2
+gfn.DamerauLevenshteinString("form", "from")   // 1
+*/
+
+// DamerauLevenshteinString returns the Damerau-Levenshtein distance of two
+// strings, counted by runes.
+func DamerauLevenshteinString(a, b string) int {
+	return DamerauLevenshtein([]rune(a), []rune(b))
+}
+
+/* @example LongestCommonSubsequence
+gfn.LongestCommonSubsequence([]int{1, 2, 3, 4, 5}, []int{2, 4, 3, 5})  // []int{2, 4, 5}
+*/
+
+// LongestCommonSubsequence returns a longest array whose values appear in
+// both arrays in the same order, not necessarily adjacent. If there are
+// several, the one kept by EditScript is returned.
+func LongestCommonSubsequence[T comparable](a, b []T) []T {
+	res := []T{}
+	for _, edit := range EditScript(a, b) {
+		if edit.Op == EditEqual {
+			res = append(res, edit.Value)
+		}
+	}
+	return res
+}
+
+/* @example LongestCommonPrefix
+gfn.LongestCommonPrefix([]int{1, 2, 3}, []int{1, 2, 4}, []int{1, 2})  // []int{1, 2}
+*/
+
+// LongestCommonPrefix returns a new array with the longest prefix shared by
+// all arrays. It returns an empty array if no array is given.
+func LongestCommonPrefix[T comparable](arrays ...[]T) []T {
+	n := commonLength(arrays, func(array []T, i int) T {
+		return array[i]
+	})
+	if n == 0 {
+		return []T{}
+	}
+	return Copy(arrays[0][:n])
+}
+
+/* @example LongestCommonSuffix
+gfn.LongestCommonSuffix([]int{1, 2, 3}, []int{4, 2, 3}, []int{3})  // []int{3}
+*/
+
+// LongestCommonSuffix returns a new array with the longest suffix shared by
+// all arrays. It returns an empty array if no array is given.
+func LongestCommonSuffix[T comparable](arrays ...[]T) []T {
+	n := commonLength(arrays, func(array []T, i int) T {
+		return array[len(array)-1-i]
+	})
+	if n == 0 {
+		return []T{}
+	}
+	return Copy(arrays[0][len(arrays[0])-n:])
+}
+
+// commonLength returns the number of leading positions where at(array, i)
+// is the same for all arrays.
+func commonLength[T comparable](arrays [][]T, at func(array []T, i int) T) int {
+	if len(arrays) == 0 {
+		return 0
+	}
+
+	n := Min(Map(arrays, func(array []T) int { return len(array) })...)
+	for i := 0; i < n; i++ {
+		v := at(arrays[0], i)
+		for _, array := range arrays[1:] {
+			if at(array, i) != v {
+				return i
+			}
+		}
+	}
+	return n
+}
+
+/* @example Jaccard
+gfn.Jaccard([]int{1, 2, 3}, []int{2, 3, 4})  // 0.5
+*/
+
+// Jaccard returns the Jaccard similarity of two arrays as sets, which is the
+// size of their intersection divided by the size of their union, between 0
+// and 1. Duplicates are ignored. Two empty arrays have a similarity of 1.
+func Jaccard[T comparable](a, b []T) float64 {
+	union := Union(a, b)
+	if len(union) == 0 {
+		return 1
+	}
+	return float64(len(Intersection(a, b))) / float64(len(union))
+}
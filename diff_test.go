@@ -0,0 +1,266 @@
+package gfn_test
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+// lcsLength returns the length of the longest common subsequence by dynamic programming.
+func lcsLength(a, b []int) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else {
+				dp[i][j] = Max(dp[i-1][j], dp[i][j-1])
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
+
+func TestEditScript(t *testing.T) {
+	AssertSliceEqual(t, []Edit[string]{
+		{EditEqual, "a"},
+		{EditDelete, "b"},
+		{EditEqual, "c"},
+		{EditInsert, "d"},
+	}, EditScript([]string{"a", "b", "c"}, []string{"a", "c", "d"}))
+	AssertSliceEqual(t, []Edit[int]{}, EditScript([]int{}, []int{}))
+	AssertSliceEqual(t, []Edit[int]{{EditInsert, 1}, {EditInsert, 2}}, EditScript([]int{}, []int{1, 2}))
+	AssertSliceEqual(t, []Edit[int]{{EditDelete, 1}, {EditDelete, 2}}, EditScript([]int{1, 2}, nil))
+	AssertSliceEqual(t, []Edit[int]{{EditDelete, 1}, {EditInsert, 2}}, EditScript([]int{1}, []int{2}))
+	AssertSliceEqual(t, []Edit[int]{{EditEqual, 1}, {EditEqual, 2}}, EditScript([]int{1, 2}, []int{1, 2}))
+
+	// the script is the shortest and reproduces b
+	for i := 0; i < 200; i++ {
+		a := make([]int, rand.Intn(20))
+		for j := range a {
+			a[j] = rand.Intn(4)
+		}
+		b := make([]int, rand.Intn(20))
+		for j := range b {
+			b[j] = rand.Intn(4)
+		}
+		script := EditScript(a, b)
+		AssertSliceEqual(t, b, Patch(a, script))
+		changes := CountBy(script, func(e Edit[int]) bool {
+			return e.Op != EditEqual
+		})
+		AssertEqual(t, len(a)+len(b)-2*lcsLength(a, b), changes)
+	}
+
+	// large inputs that share nothing
+	a := Range(0, 4000)
+	b := Range(4000, 8000)
+	script := EditScript(a, b)
+	AssertEqual(t, 8000, len(script))
+	AssertTrue(t, All(script[:4000], func(e Edit[int]) bool { return e.Op == EditDelete }))
+	AssertSliceEqual(t, b, Patch(a, script))
+
+	// large inputs with a few changes
+	a = Range(0, 100000)
+	b = Copy(a)
+	b[10] = -1
+	b = append(b[:5000], b[5100:]...)
+	b = append(b, -2)
+	script = EditScript(a, b)
+	AssertSliceEqual(t, b, Patch(a, script))
+	AssertEqual(t, 103, CountBy(script, func(e Edit[int]) bool {
+		return e.Op != EditEqual
+	}))
+}
+
+func TestEditScriptBy(t *testing.T) {
+	type Route struct {
+		prefix  string
+		nextHop string
+	}
+	a := []Route{{"10.0.0.0/8", "r1"}, {"192.168.0.0/16", "r2"}}
+	b := []Route{{"10.0.0.0/8", "r3"}, {"172.16.0.0/12", "r2"}}
+	script := EditScriptBy(a, b, func(r Route) string {
+		return r.prefix
+	})
+	AssertSliceEqual(t, []Edit[Route]{
+		{EditEqual, Route{"10.0.0.0/8", "r3"}},
+		{EditDelete, Route{"192.168.0.0/16", "r2"}},
+		{EditInsert, Route{"172.16.0.0/12", "r2"}},
+	}, script)
+	AssertSliceEqual(t, b, Patch(a, script))
+
+	AssertSliceEqual(t, []Edit[string]{{EditEqual, "B"}, {EditInsert, "c"}}, EditScriptBy([]string{"b"}, []string{"B", "c"}, strings.ToLower))
+}
+
+func TestPatch(t *testing.T) {
+	a := []int{1, 2, 3}
+	b := []int{1, 3, 4}
+	AssertSliceEqual(t, b, Patch(a, EditScript(a, b)))
+	AssertSliceEqual(t, []int{}, Patch([]int{}, []Edit[int]{}))
+	AssertSliceEqual(t, []int{5}, Patch([]int{}, []Edit[int]{{EditInsert, 5}}))
+
+	AssertPanics(t, func() {
+		Patch([]int{1}, []Edit[int]{})
+	})
+	AssertPanics(t, func() {
+		Patch([]int{1}, []Edit[int]{{EditDelete, 1}, {EditEqual, 2}})
+	})
+	AssertPanics(t, func() {
+		Patch([]int{1}, []Edit[int]{{EditOp(10), 1}})
+	})
+}
+
+func TestRenderUnifiedDiff(t *testing.T) {
+	identity := func(s string) string {
+		return s
+	}
+	a := []string{"a", "b", "c", "d", "e", "f"}
+	b := []string{"a", "c", "d", "e", "x", "f"}
+	expected := strings.Join([]string{
+		"@@ -1,3 +1,2 @@",
+		" a",
+		"-b",
+		" c",
+		"@@ -5,2 +4,3 @@",
+		" e",
+		"+x",
+		" f",
+		"",
+	}, "\n")
+	AssertEqual(t, expected, RenderUnifiedDiff(EditScript(a, b), 1, identity))
+
+	// hunks are merged if their contexts overlap
+	expected = strings.Join([]string{
+		"@@ -1,6 +1,6 @@",
+		" a",
+		"-b",
+		" c",
+		" d",
+		" e",
+		"+x",
+		" f",
+		"",
+	}, "\n")
+	AssertEqual(t, expected, RenderUnifiedDiff(EditScript(a, b), 2, identity))
+
+	expected = strings.Join([]string{
+		"@@ -2 +1,0 @@",
+		"-b",
+		"@@ -5,0 +5 @@",
+		"+x",
+		"",
+	}, "\n")
+	AssertEqual(t, expected, RenderUnifiedDiff(EditScript(a, b), 0, identity))
+
+	AssertEqual(t, "@@ -0,0 +1,2 @@\n+1\n+2\n", RenderUnifiedDiff(EditScript([]int{}, []int{1, 2}), 3, strconv.Itoa))
+	AssertEqual(t, "", RenderUnifiedDiff(EditScript(a, a), 3, identity))
+	AssertEqual(t, "", RenderUnifiedDiff([]Edit[string]{}, 3, identity))
+
+	AssertPanics(t, func() {
+		RenderUnifiedDiff([]Edit[string]{}, -1, identity)
+	})
+}
+
+func TestLevenshtein(t *testing.T) {
+	AssertEqual(t, 2, Levenshtein([]int{1, 2, 3}, []int{1, 3, 4}))
+	AssertEqual(t, 0, Levenshtein([]int{}, []int{}))
+	AssertEqual(t, 3, Levenshtein([]int{}, []int{1, 2, 3}))
+	AssertEqual(t, 3, Levenshtein([]int{1, 2, 3}, nil))
+	AssertEqual(t, 2, Levenshtein([]int{1, 2}, []int{2, 1}))
+	AssertEqual(t, 0, Levenshtein([]string{"a", "b"}, []string{"a", "b"}))
+}
+
+func TestLevenshteinString(t *testing.T) {
+	AssertEqual(t, 3, LevenshteinString("kitten", "sitting"))
+	AssertEqual(t, 3, LevenshteinString("sitting", "kitten"))
+	AssertEqual(t, 1, LevenshteinString("café", "cafe"))
+	AssertEqual(t, 2, LevenshteinString("flaw", "lawn"))
+	AssertEqual(t, 4, LevenshteinString("", "abcd"))
+}
+
+func TestDamerauLevenshtein(t *testing.T) {
+	AssertEqual(t, 1, DamerauLevenshtein([]int{1, 2, 3}, []int{2, 1, 3}))
+	AssertEqual(t, 0, DamerauLevenshtein([]int{}, []int{}))
+	AssertEqual(t, 2, DamerauLevenshtein([]int{}, []int{1, 2}))
+	AssertEqual(t, 2, DamerauLevenshtein([]int{1, 2}, nil))
+
+	// never more than Levenshtein, and the same without transpositions
+	for i := 0; i < 200; i++ {
+		a := make([]int, rand.Intn(10))
+		for j := range a {
+			a[j] = rand.Intn(4)
+		}
+		b := make([]int, rand.Intn(10))
+		for j := range b {
+			b[j] = rand.Intn(4)
+		}
+		AssertTrue(t, DamerauLevenshtein(a, b) <= Levenshtein(a, b))
+		AssertEqual(t, DamerauLevenshtein(a, b), DamerauLevenshtein(b, a))
+	}
+}
+
+func TestDamerauLevenshteinString(t *testing.T) {
+	AssertEqual(t, 2, DamerauLevenshteinString("ca", "abc"))
+	AssertEqual(t, 1, DamerauLevenshteinString("form", "from"))
+	AssertEqual(t, 3, DamerauLevenshteinString("kitten", "sitting"))
+	AssertEqual(t, 1, DamerauLevenshteinString("日本", "本日"))
+	AssertEqual(t, 3, DamerauLevenshteinString("abcdef", "badcfe"))
+}
+
+func TestLongestCommonSubsequence(t *testing.T) {
+	lcs := LongestCommonSubsequence([]int{1, 2, 3, 4, 5}, []int{2, 4, 3, 5})
+	AssertTrue(t, Equal(lcs, []int{2, 3, 5}) || Equal(lcs, []int{2, 4, 5}))
+	AssertSliceEqual(t, []int{}, LongestCommonSubsequence([]int{1, 2}, []int{3}))
+	AssertSliceEqual(t, []int{}, LongestCommonSubsequence([]int{}, []int{}))
+	AssertSliceEqual(t, []rune("ace"), LongestCommonSubsequence([]rune("abcde"), []rune("ace")))
+
+	for i := 0; i < 100; i++ {
+		a := make([]int, rand.Intn(15))
+		for j := range a {
+			a[j] = rand.Intn(3)
+		}
+		b := make([]int, rand.Intn(15))
+		for j := range b {
+			b[j] = rand.Intn(3)
+		}
+		AssertEqual(t, lcsLength(a, b), len(LongestCommonSubsequence(a, b)))
+	}
+}
+
+func TestLongestCommonPrefix(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 2}, LongestCommonPrefix([]int{1, 2, 3}, []int{1, 2, 4}, []int{1, 2}))
+	AssertSliceEqual(t, []int{1, 2, 3}, LongestCommonPrefix([]int{1, 2, 3}))
+	AssertSliceEqual(t, []int{}, LongestCommonPrefix([]int{1}, []int{2}))
+	AssertSliceEqual(t, []int{}, LongestCommonPrefix([]int{1}, []int{}))
+	AssertSliceEqual(t, []int{}, LongestCommonPrefix[int]())
+
+	// the result is a copy
+	a := []int{1, 2}
+	prefix := LongestCommonPrefix(a, a)
+	prefix[0] = 10
+	AssertSliceEqual(t, []int{1, 2}, a)
+}
+
+func TestLongestCommonSuffix(t *testing.T) {
+	AssertSliceEqual(t, []int{3}, LongestCommonSuffix([]int{1, 2, 3}, []int{4, 2, 3}, []int{3}))
+	AssertSliceEqual(t, []int{2, 3}, LongestCommonSuffix([]int{1, 2, 3}, []int{4, 2, 3}))
+	AssertSliceEqual(t, []string{"c"}, LongestCommonSuffix([]string{"a", "c"}, []string{"b", "c"}))
+	AssertSliceEqual(t, []int{}, LongestCommonSuffix([]int{1}, []int{2}))
+	AssertSliceEqual(t, []int{}, LongestCommonSuffix[int]())
+}
+
+func TestJaccard(t *testing.T) {
+	AssertFloatEqual(t, 0.5, Jaccard([]int{1, 2, 3}, []int{2, 3, 4}))
+	AssertFloatEqual(t, 0.5, Jaccard([]int{1, 1, 2}, []int{2, 2}))
+	AssertFloatEqual(t, 0, Jaccard([]int{1}, []int{2}))
+	AssertFloatEqual(t, 0, Jaccard([]int{1}, []int{}))
+	AssertFloatEqual(t, 1, Jaccard([]int{}, []int{}))
+	AssertFloatEqual(t, 1, Jaccard([]string{"a", "b"}, []string{"b", "a"}))
+}
@@ -69,6 +69,41 @@ func Reduce[T any, R any](array []T, init R, fn func(R, T) R) R {
 	return result
 }
 
+/* @example Scan
+gfn.Scan([]int{1, 2, 3, 4}, 0, func(a, b int) int {
+	return a + b
+})
+// []int{1, 3, 6, 10}
+*/
+
+// Scan executes a reducer function on each element of the array like Reduce,
+// but returns every intermediate accumulator. The result has the same length
+// as the array and its last element equals the result of Reduce.
+func Scan[T any, R any](array []T, init R, fn func(R, T) R) []R {
+	result := make([]R, len(array))
+	acc := init
+	for i, v := range array {
+		acc = fn(acc, v)
+		result[i] = acc
+	}
+	return result
+}
+
+/* @example ScanLeft
+gfn.ScanLeft([]int{1, 2, 3, 4}, 0, func(a, b int) int {
+	return a + b
+})
+// []int{0, 1, 3, 6, 10}
+*/
+
+// ScanLeft is like Scan but the result starts with init, so it has one more
+// element than the array.
+func ScanLeft[T any, R any](array []T, init R, fn func(R, T) R) []R {
+	result := make([]R, 0, len(array)+1)
+	result = append(result, init)
+	return append(result, Scan(array, init, fn)...)
+}
+
 /* @example ReduceKV
 m := map[string]int{"a": 1, "b": 2, "c": 3}
 total := gfn.ReduceKV(m, 0, func(value int, k string, v int) int {
@@ -61,6 +61,33 @@ func TestReduce(t *testing.T) {
 	}))
 }
 
+func TestScan(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 3, 6, 10}, Scan([]int{1, 2, 3, 4}, 0, func(a, b int) int {
+		return a + b
+	}))
+	AssertSliceEqual(t, []string{"a", "ab", "abc"}, Scan([]string{"a", "b", "c"}, "", func(acc, s string) string {
+		return acc + s
+	}))
+	AssertSliceEqual(t, []int{1, 2, 3}, Scan([]string{"a", "bb", "ccc"}, 100, func(acc int, s string) int {
+		return len(s)
+	}))
+	AssertSliceEqual(t, []int{}, Scan([]int{}, 0, func(a, b int) int {
+		return a + b
+	}))
+}
+
+func TestScanLeft(t *testing.T) {
+	AssertSliceEqual(t, []int{0, 1, 3, 6, 10}, ScanLeft([]int{1, 2, 3, 4}, 0, func(a, b int) int {
+		return a + b
+	}))
+	AssertSliceEqual(t, []int{1, 2, 6, 24}, ScanLeft([]int{2, 3, 4}, 1, func(a, b int) int {
+		return a * b
+	}))
+	AssertSliceEqual(t, []int{5}, ScanLeft([]int{}, 5, func(a, b int) int {
+		return a + b
+	}))
+}
+
 func TestFilterKV(t *testing.T) {
 	m := map[int]string{1: "a", 2: "b", 3: "c"}
 	m = FilterKV(m, func(k int, v string) bool {
@@ -0,0 +1,156 @@
+package gfn
+
+// RaggedPolicy decides how Transpose handles rows of different lengths.
+type RaggedPolicy int
+
+const (
+	// RaggedPanic panics if the rows have different lengths.
+	RaggedPanic RaggedPolicy = iota
+	// RaggedTruncate drops the values beyond the length of the shortest row.
+	RaggedTruncate
+	// RaggedPad pads the shorter rows with zero values up to the length of the longest row.
+	RaggedPad
+)
+
+/* @example Transpose
+gfn.Transpose([][]int{{1, 2, 3}, {4, 5, 6}}, gfn.RaggedPanic)
+// [][]int{{1, 4}, {2, 5}, {3, 6}}
+gfn.Transpose([][]int{{1, 2, 3}, {4}}, gfn.RaggedTruncate)
+// [][]int{{1, 4}}
+gfn.Transpose([][]int{{1, 2, 3}, {4}}, gfn.RaggedPad)
+// [][]int{{1, 4}, {2, 0}, {3, 0}}
+*/
+
+// Transpose returns a new grid whose rows are the columns of the given grid.
+// Rows of different lengths are handled according to policy.
+func Transpose[T any](grid [][]T, policy RaggedPolicy) [][]T {
+	cols := gridWidth(grid, policy)
+	res := make([][]T, cols)
+	for j := range res {
+		res[j] = make([]T, len(grid))
+		for i, row := range grid {
+			if j < len(row) {
+				res[j][i] = row[j]
+			}
+		}
+	}
+	return res
+}
+
+// gridWidth returns the number of columns of the grid under the given policy.
+func gridWidth[T any](grid [][]T, policy RaggedPolicy) int {
+	if len(grid) == 0 {
+		return 0
+	}
+
+	lengths := Map(grid, func(row []T) int { return len(row) })
+	shortest, longest := MinMax(lengths...)
+	switch policy {
+	case RaggedPanic:
+		if shortest != longest {
+			panic("rows have different lengths")
+		}
+		return longest
+	case RaggedTruncate:
+		return shortest
+	case RaggedPad:
+		return longest
+	default:
+		panic("invalid ragged policy")
+	}
+}
+
+/* @example Column
+gfn.Column([][]int{{1, 2, 3}, {4, 5, 6}}, 1)  // []int{2, 5}
+*/
+
+// Column returns the i-th value of every row. It panics if a row has no i-th value.
+func Column[T any](grid [][]T, i int) []T {
+	return Map(grid, func(row []T) T {
+		if i < 0 || i >= len(row) {
+			panic("column index out of range")
+		}
+		return row[i]
+	})
+}
+
+/* @example MapRows
+gfn.MapRows([][]int{{1, 2, 3}, {4, 5, 6}}, func(row []int) int {
+	return gfn.Sum(row...)
+})  // []int{6, 15}
+*/
+
+// MapRows returns a new array populated with the results of calling the
+// mapper on every row of the grid.
+func MapRows[T any, R any](grid [][]T, mapper func([]T) R) []R {
+	return Map(grid, mapper)
+}
+
+/* @example MapColumns
+gfn.MapColumns([][]int{{1, 2, 3}, {4, 5, 6}}, func(col []int) int {
+	return gfn.Sum(col...)
+})  // []int{5, 7, 9}
+*/
+
+// MapColumns returns a new array populated with the results of calling the
+// mapper on every column of the grid. It panics if the rows have different lengths.
+func MapColumns[T any, R any](grid [][]T, mapper func([]T) R) []R {
+	return Map(Transpose(grid, RaggedPanic), mapper)
+}
+
+/* @example ReduceRows
+gfn.ReduceRows([][]int{{1, 2, 3}, {4, 5, 6}}, 1, func(acc, v int) int {
+	return acc * v
+})  // []int{6, 120}
+*/
+
+// ReduceRows reduces every row of the grid with the same init value and fn,
+// and returns one result per row.
+func ReduceRows[T any, R any](grid [][]T, init R, fn func(R, T) R) []R {
+	return Map(grid, func(row []T) R {
+		return Reduce(row, init, fn)
+	})
+}
+
+/* @example ReduceColumns
+gfn.ReduceColumns([][]int{{1, 2, 3}, {4, 5, 6}}, 1, func(acc, v int) int {
+	return acc * v
+})  // []int{4, 10, 18}
+*/
+
+// ReduceColumns reduces every column of the grid with the same init value
+// and fn, and returns one result per column. It panics if the rows have
+// different lengths.
+func ReduceColumns[T any, R any](grid [][]T, init R, fn func(R, T) R) []R {
+	return ReduceRows(Transpose(grid, RaggedPanic), init, fn)
+}
+
+/* @example Rotate90
+gfn.Rotate90([][]int{{1, 2, 3}, {4, 5, 6}})
+// [][]int{{4, 1}, {5, 2}, {6, 3}}
+*/
+
+// Rotate90 returns a new grid rotated 90 degrees clockwise. Rotate three
+// times to rotate counterclockwise. It panics if the rows have different lengths.
+func Rotate90[T any](grid [][]T) [][]T {
+	res := Transpose(grid, RaggedPanic)
+	for _, row := range res {
+		Reverse(row)
+	}
+	return res
+}
+
+/* @example FlattenGrid
+grid := gfn.Chunk([]int{1, 2, 3, 4, 5}, 2)  // [][]int{{1, 2}, {3, 4}, {5}}
+gfn.FlattenGrid(grid)                       // []int{1, 2, 3, 4, 5}
+*/
+
+// FlattenGrid returns a new array with the rows of the grid joined in order.
+// It is the inverse of Chunk.
+func FlattenGrid[T any](grid [][]T) []T {
+	res := Concat(grid...)
+	if res == nil {
+		return []T{}
+	}
+	return res
+}
@@ -0,0 +1,119 @@
+package gfn_test
+
+import (
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func assertGridEqual[T comparable](t *testing.T, expected, actual [][]T) {
+	t.Helper()
+	AssertEqual(t, len(expected), len(actual))
+	for i := range expected {
+		AssertSliceEqual(t, expected[i], actual[i])
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	assertGridEqual(t, [][]int{{1, 4}, {2, 5}, {3, 6}}, Transpose([][]int{{1, 2, 3}, {4, 5, 6}}, RaggedPanic))
+	assertGridEqual(t, [][]int{{1}, {2}}, Transpose([][]int{{1, 2}}, RaggedPanic))
+	assertGridEqual(t, [][]int{}, Transpose([][]int{}, RaggedPanic))
+	assertGridEqual(t, [][]int{}, Transpose([][]int{{}, {}}, RaggedPanic))
+
+	assertGridEqual(t, [][]int{{1, 4}}, Transpose([][]int{{1, 2, 3}, {4}}, RaggedTruncate))
+	assertGridEqual(t, [][]int{}, Transpose([][]int{{1, 2, 3}, {}}, RaggedTruncate))
+	assertGridEqual(t, [][]int{{1, 4}, {2, 0}, {3, 0}}, Transpose([][]int{{1, 2, 3}, {4}}, RaggedPad))
+	assertGridEqual(t, [][]string{{"", "a"}}, Transpose([][]string{{}, {"a"}}, RaggedPad))
+
+	// transposing twice returns the original grid
+	grid := Chunk(Range(0, 12), 4)
+	assertGridEqual(t, grid, Transpose(Transpose(grid, RaggedPanic), RaggedPanic))
+
+	AssertPanics(t, func() {
+		Transpose([][]int{{1, 2}, {3}}, RaggedPanic)
+	})
+	AssertPanics(t, func() {
+		Transpose([][]int{{1}}, RaggedPolicy(10))
+	})
+}
+
+func TestColumn(t *testing.T) {
+	AssertSliceEqual(t, []int{2, 5}, Column([][]int{{1, 2, 3}, {4, 5, 6}}, 1))
+	AssertSliceEqual(t, []int{1, 4}, Column([][]int{{1, 2, 3}, {4}}, 0))
+	AssertSliceEqual(t, []int{}, Column([][]int{}, 3))
+
+	AssertPanics(t, func() {
+		Column([][]int{{1, 2, 3}, {4}}, 1)
+	})
+	AssertPanics(t, func() {
+		Column([][]int{{1}}, -1)
+	})
+}
+
+func TestMapRows(t *testing.T) {
+	AssertSliceEqual(t, []int{6, 15}, MapRows([][]int{{1, 2, 3}, {4, 5, 6}}, func(row []int) int {
+		return Sum(row...)
+	}))
+	AssertSliceEqual(t, []int{3, 0}, MapRows([][]string{{"a", "b", "c"}, {}}, func(row []string) int {
+		return len(row)
+	}))
+}
+
+func TestMapColumns(t *testing.T) {
+	AssertSliceEqual(t, []int{5, 7, 9}, MapColumns([][]int{{1, 2, 3}, {4, 5, 6}}, func(col []int) int {
+		return Sum(col...)
+	}))
+	AssertSliceEqual(t, []int{}, MapColumns([][]int{}, func(col []int) int {
+		return Sum(col...)
+	}))
+
+	AssertPanics(t, func() {
+		MapColumns([][]int{{1, 2}, {3}}, func(col []int) int {
+			return len(col)
+		})
+	})
+}
+
+func TestReduceRows(t *testing.T) {
+	AssertSliceEqual(t, []int{6, 120}, ReduceRows([][]int{{1, 2, 3}, {4, 5, 6}}, 1, func(acc, v int) int {
+		return acc * v
+	}))
+	AssertSliceEqual(t, []string{"ab", ""}, ReduceRows([][]string{{"a", "b"}, {}}, "", func(acc, v string) string {
+		return acc + v
+	}))
+}
+
+func TestReduceColumns(t *testing.T) {
+	AssertSliceEqual(t, []int{4, 10, 18}, ReduceColumns([][]int{{1, 2, 3}, {4, 5, 6}}, 1, func(acc, v int) int {
+		return acc * v
+	}))
+
+	AssertPanics(t, func() {
+		ReduceColumns([][]int{{1, 2}, {3}}, 0, func(acc, v int) int {
+			return acc + v
+		})
+	})
+}
+
+func TestRotate90(t *testing.T) {
+	grid := [][]int{{1, 2, 3}, {4, 5, 6}}
+	assertGridEqual(t, [][]int{{4, 1}, {5, 2}, {6, 3}}, Rotate90(grid))
+	assertGridEqual(t, [][]int{{6, 5, 4}, {3, 2, 1}}, Rotate90(Rotate90(grid)))
+	assertGridEqual(t, [][]int{{3, 6}, {2, 5}, {1, 4}}, Rotate90(Rotate90(Rotate90(grid))))
+	assertGridEqual(t, grid, Rotate90(Rotate90(Rotate90(Rotate90(grid)))))
+	assertGridEqual(t, [][]int{}, Rotate90([][]int{}))
+
+	// the original grid is not modified
+	assertGridEqual(t, [][]int{{1, 2, 3}, {4, 5, 6}}, grid)
+
+	AssertPanics(t, func() {
+		Rotate90([][]int{{1, 2}, {3}})
+	})
+}
+
+func TestFlattenGrid(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 2, 3, 4, 5}, FlattenGrid(Chunk([]int{1, 2, 3, 4, 5}, 2)))
+	AssertSliceEqual(t, []int{1, 2}, FlattenGrid([][]int{{}, {1}, {}, {2}}))
+	AssertSliceEqual(t, []int{}, FlattenGrid([][]int{}))
+	AssertSliceEqual(t, []int{}, FlattenGrid([][]int{{}, {}}))
+}
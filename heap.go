@@ -0,0 +1,311 @@
+package gfn
+
+// Heap is a binary heap ordered by a less function. The element for which
+// less reports true against every other element is at the top.
+// Heap is not safe for concurrent use.
+type Heap[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+/* @example NewHeap
+h := gfn.NewHeap(func(a, b int) bool { return a < b }, 5, 2, 8)
+h.Push(1)
+h.Peek()  // 1
+h.Pop()   // 1
+h.Pop()   // 2
+h.Len()   // 2
+
+// max heap
+h2 := gfn.NewHeap(func(a, b int) bool { return a > b }, 5, 2, 8)
+h2.Pop()  // 8
+*/
+
+// NewHeap returns a heap ordered by less and initialized with given values in O(n).
+func NewHeap[T any](less func(a, b T) bool, values ...T) *Heap[T] {
+	h := &Heap[T]{data: Copy(values), less: less}
+	n := len(h.data)
+	for i := n/2 - 1; i >= 0; i-- {
+		siftDown(i, n, h.lessAt, h.swap)
+	}
+	return h
+}
+
+func (h *Heap[T]) lessAt(i, j int) bool {
+	return h.less(h.data[i], h.data[j])
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push adds a value to the heap in O(log n).
+func (h *Heap[T]) Push(value T) {
+	h.data = append(h.data, value)
+	siftUp(len(h.data)-1, h.lessAt, h.swap)
+}
+
+// Pop removes and returns the top element of the heap in O(log n).
+// Pop panics if the heap is empty.
+func (h *Heap[T]) Pop() T {
+	if len(h.data) == 0 {
+		panic("heap is empty")
+	}
+	n := len(h.data) - 1
+	h.swap(0, n)
+	siftDown(0, n, h.lessAt, h.swap)
+	res := h.data[n]
+	var zero T
+	h.data[n] = zero
+	h.data = h.data[:n]
+	return res
+}
+
+// Peek returns the top element of the heap without removing it.
+// Peek panics if the heap is empty.
+func (h *Heap[T]) Peek() T {
+	if len(h.data) == 0 {
+		panic("heap is empty")
+	}
+	return h.data[0]
+}
+
+// Values returns a copy of the heap elements in their internal order, which
+// is the order used by the index of Fix. Index 0 is always the top element.
+func (h *Heap[T]) Values() []T {
+	return Copy(h.data)
+}
+
+// Fix replaces the element at index i with value and re-establishes the heap
+// ordering in O(log n). The index refers to the order returned by Values.
+func (h *Heap[T]) Fix(i int, value T) {
+	if i < 0 || i >= len(h.data) {
+		panic("index out of range")
+	}
+	h.data[i] = value
+	if !siftDown(i, len(h.data), h.lessAt, h.swap) {
+		siftUp(i, h.lessAt, h.swap)
+	}
+}
+
+// siftUp moves the element at index i up until its parent is not greater than it.
+func siftUp(i int, less func(i, j int) bool, swap func(i, j int)) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !less(i, parent) {
+			break
+		}
+		swap(i, parent)
+		i = parent
+	}
+}
+
+// siftDown moves the element at index i down within the first n elements
+// until neither of its children is less than it. It reports whether the element moved.
+func siftDown(i, n int, less func(i, j int) bool, swap func(i, j int)) bool {
+	start := i
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && less(right, child) {
+			child = right
+		}
+		if !less(child, i) {
+			break
+		}
+		swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// PriorityQueue is a heap of unique keys ordered by their priorities. The
+// priority of a key already in the queue can be updated in O(log n).
+// PriorityQueue is not safe for concurrent use.
+type PriorityQueue[K comparable, P any] struct {
+	items []Pair[K, P]
+	index map[K]int
+	less  func(a, b P) bool
+}
+
+/* @example NewPriorityQueue
+pq := gfn.NewPriorityQueue[string](func(a, b int) bool { return a < b })
+pq.Push("write code", 2)
+pq.Push("fix bug", 1)
+pq.Push("write doc", 3)
+pq.Push("write doc", 0)  // update priority
+pq.Pop()  // "write doc", 0
+pq.Pop()  // "fix bug", 1
+*/
+
+// NewPriorityQueue returns an empty priority queue ordered by less, the key
+// with the smallest priority according to less is popped first.
+func NewPriorityQueue[K comparable, P any](less func(a, b P) bool) *PriorityQueue[K, P] {
+	return &PriorityQueue[K, P]{
+		index: make(map[K]int),
+		less:  less,
+	}
+}
+
+func (pq *PriorityQueue[K, P]) lessAt(i, j int) bool {
+	return pq.less(pq.items[i].Second, pq.items[j].Second)
+}
+
+func (pq *PriorityQueue[K, P]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.index[pq.items[i].First] = i
+	pq.index[pq.items[j].First] = j
+}
+
+// Len returns the number of keys in the priority queue.
+func (pq *PriorityQueue[K, P]) Len() int {
+	return len(pq.items)
+}
+
+// Push adds key with given priority to the priority queue. If key is already
+// in the queue, its priority is updated instead.
+func (pq *PriorityQueue[K, P]) Push(key K, priority P) {
+	if i, ok := pq.index[key]; ok {
+		pq.items[i].Second = priority
+		if !siftDown(i, len(pq.items), pq.lessAt, pq.swap) {
+			siftUp(i, pq.lessAt, pq.swap)
+		}
+		return
+	}
+	pq.items = append(pq.items, Pair[K, P]{key, priority})
+	pq.index[key] = len(pq.items) - 1
+	siftUp(len(pq.items)-1, pq.lessAt, pq.swap)
+}
+
+// Pop removes and returns the key with the top priority and its priority.
+// Pop panics if the priority queue is empty.
+func (pq *PriorityQueue[K, P]) Pop() (K, P) {
+	if len(pq.items) == 0 {
+		panic("priority queue is empty")
+	}
+	top := pq.items[0]
+	pq.removeAt(0)
+	return top.First, top.Second
+}
+
+// Peek returns the key with the top priority and its priority without removing it.
+// Peek panics if the priority queue is empty.
+func (pq *PriorityQueue[K, P]) Peek() (K, P) {
+	if len(pq.items) == 0 {
+		panic("priority queue is empty")
+	}
+	return pq.items[0].First, pq.items[0].Second
+}
+
+// Priority returns the priority of key and true if key is in the priority
+// queue, otherwise it returns the zero value and false.
+func (pq *PriorityQueue[K, P]) Priority(key K) (P, bool) {
+	if i, ok := pq.index[key]; ok {
+		return pq.items[i].Second, true
+	}
+	var zero P
+	return zero, false
+}
+
+// Contains returns true if key is in the priority queue.
+func (pq *PriorityQueue[K, P]) Contains(key K) bool {
+	_, ok := pq.index[key]
+	return ok
+}
+
+// Remove removes key from the priority queue. It returns false if key is
+// not in the priority queue.
+func (pq *PriorityQueue[K, P]) Remove(key K) bool {
+	i, ok := pq.index[key]
+	if !ok {
+		return false
+	}
+	pq.removeAt(i)
+	return true
+}
+
+func (pq *PriorityQueue[K, P]) removeAt(i int) {
+	n := len(pq.items) - 1
+	if i != n {
+		pq.swap(i, n)
+	}
+	delete(pq.index, pq.items[n].First)
+	pq.items[n] = Pair[K, P]{}
+	pq.items = pq.items[:n]
+	if i != n && !siftDown(i, n, pq.lessAt, pq.swap) {
+		siftUp(i, pq.lessAt, pq.swap)
+	}
+}
+
+/* @example MergeSorted
+gfn.MergeSorted([]int{1, 4, 7}, []int{2, 5, 8}, []int{3, 6, 9})
+// []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+*/
+
+// MergeSorted merges arrays sorted in ascending order into a single sorted
+// array by using a heap-based k-way merge, in O(n log k) time where n is the
+// total number of elements and k is the number of arrays.
+// The result is unspecified if any array is not sorted.
+func MergeSorted[T Int | Uint | Float | ~string](arrays ...[]T) []T {
+	return MergeSortedBy(func(a, b T) bool { return a < b }, arrays...)
+}
+
+/* @example MergeSortedBy
+type Event struct {
+	name string
+	time int
+}
+shard1 := []Event{{"a", 1}, {"c", 5}}
+shard2 := []Event{{"b", 3}, {"d", 5}}
+gfn.MergeSortedBy(func(a, b Event) bool {
+	return a.time < b.time
+}, shard1, shard2)
+// []Event{{"a", 1}, {"b", 3}, {"c", 5}, {"d", 5}}
+*/
+
+// MergeSortedBy merges arrays sorted by less into a single sorted array by
+// using a heap-based k-way merge. The merge is stable, equal elements keep
+// the order of their arrays in the arguments.
+func MergeSortedBy[T any](less func(a, b T) bool, arrays ...[]T) []T {
+	total := 0
+	for _, array := range arrays {
+		total += len(array)
+	}
+	res := make([]T, 0, total)
+
+	// cursor is (array index, element index)
+	cursors := make([]Pair[int, int], 0, len(arrays))
+	for i, array := range arrays {
+		if len(array) > 0 {
+			cursors = append(cursors, Pair[int, int]{i, 0})
+		}
+	}
+	h := NewHeap(func(a, b Pair[int, int]) bool {
+		va, vb := arrays[a.First][a.Second], arrays[b.First][b.Second]
+		if less(va, vb) {
+			return true
+		}
+		if less(vb, va) {
+			return false
+		}
+		return a.First < b.First
+	}, cursors...)
+
+	for h.Len() > 0 {
+		c := h.Peek()
+		res = append(res, arrays[c.First][c.Second])
+		if c.Second+1 < len(arrays[c.First]) {
+			h.Fix(0, Pair[int, int]{c.First, c.Second + 1})
+		} else {
+			h.Pop()
+		}
+	}
+	return res
+}
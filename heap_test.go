@@ -0,0 +1,206 @@
+package gfn_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func TestNewHeap(t *testing.T) {
+	h := NewHeap(func(a, b int) bool { return a < b }, 5, 2, 8, 1, 9, 3)
+	AssertEqual(t, 6, h.Len())
+	AssertEqual(t, 1, h.Peek())
+	h.Push(0)
+	h.Push(7)
+	res := []int{}
+	for h.Len() > 0 {
+		res = append(res, h.Pop())
+	}
+	AssertSliceEqual(t, []int{0, 1, 2, 3, 5, 7, 8, 9}, res)
+
+	// max heap
+	maxHeap := NewHeap(func(a, b string) bool { return a > b }, "b", "a", "c")
+	AssertEqual(t, "c", maxHeap.Pop())
+	AssertEqual(t, "b", maxHeap.Pop())
+	AssertEqual(t, "a", maxHeap.Pop())
+
+	// values are copied
+	values := []int{3, 2, 1}
+	h = NewHeap(func(a, b int) bool { return a < b }, values...)
+	h.Pop()
+	AssertSliceEqual(t, []int{3, 2, 1}, values)
+
+	// random
+	for i := 0; i < 100; i++ {
+		array := make([]int, rand.Intn(100))
+		for j := range array {
+			array[j] = rand.Intn(50)
+		}
+		h := NewHeap(func(a, b int) bool { return a < b })
+		for _, v := range array {
+			h.Push(v)
+		}
+		res := []int{}
+		for h.Len() > 0 {
+			res = append(res, h.Pop())
+		}
+		sort.Ints(array)
+		AssertSliceEqual(t, array, res)
+	}
+
+	empty := NewHeap(func(a, b int) bool { return a < b })
+	AssertEqual(t, 0, empty.Len())
+	AssertPanics(t, func() {
+		empty.Pop()
+	})
+	AssertPanics(t, func() {
+		empty.Peek()
+	})
+}
+
+func TestHeapFix(t *testing.T) {
+	h := NewHeap(func(a, b int) bool { return a < b }, 1, 2, 3, 4, 5, 6)
+	values := h.Values()
+	AssertEqual(t, 1, values[0])
+
+	// increase top
+	h.Fix(0, 10)
+	AssertEqual(t, 2, h.Peek())
+
+	// decrease last
+	h.Fix(h.Len()-1, -1)
+	AssertEqual(t, -1, h.Peek())
+
+	res := []int{}
+	for h.Len() > 0 {
+		res = append(res, h.Pop())
+	}
+	AssertEqual(t, 6, len(res))
+	AssertTrue(t, IsSorted(res))
+	AssertEqual(t, -1, res[0])
+	AssertEqual(t, 10, res[5])
+
+	AssertPanics(t, func() {
+		h.Fix(0, 1)
+	})
+	AssertPanics(t, func() {
+		h.Fix(-1, 1)
+	})
+}
+
+func TestNewPriorityQueue(t *testing.T) {
+	pq := NewPriorityQueue[string](func(a, b int) bool { return a < b })
+	pq.Push("c", 3)
+	pq.Push("a", 1)
+	pq.Push("b", 2)
+	pq.Push("d", 4)
+	AssertEqual(t, 4, pq.Len())
+
+	key, priority := pq.Peek()
+	AssertEqual(t, "a", key)
+	AssertEqual(t, 1, priority)
+
+	// update priorities
+	pq.Push("d", 0)
+	pq.Push("a", 10)
+	p, ok := pq.Priority("a")
+	AssertTrue(t, ok)
+	AssertEqual(t, 10, p)
+	_, ok = pq.Priority("e")
+	AssertFalse(t, ok)
+	AssertEqual(t, 4, pq.Len())
+
+	AssertTrue(t, pq.Contains("b"))
+	AssertTrue(t, pq.Remove("b"))
+	AssertFalse(t, pq.Contains("b"))
+	AssertFalse(t, pq.Remove("b"))
+
+	keys := []string{}
+	priorities := []int{}
+	for pq.Len() > 0 {
+		k, p := pq.Pop()
+		keys = append(keys, k)
+		priorities = append(priorities, p)
+	}
+	AssertSliceEqual(t, []string{"d", "c", "a"}, keys)
+	AssertSliceEqual(t, []int{0, 3, 10}, priorities)
+
+	AssertPanics(t, func() {
+		pq.Pop()
+	})
+	AssertPanics(t, func() {
+		pq.Peek()
+	})
+
+	// random
+	for i := 0; i < 100; i++ {
+		pq := NewPriorityQueue[int](func(a, b int) bool { return a > b })
+		expected := map[int]int{}
+		for j := 0; j < 100; j++ {
+			key, priority := rand.Intn(30), rand.Intn(100)
+			switch rand.Intn(3) {
+			case 0, 1:
+				pq.Push(key, priority)
+				expected[key] = priority
+			case 2:
+				_, ok := expected[key]
+				AssertEqual(t, ok, pq.Remove(key))
+				delete(expected, key)
+			}
+		}
+		AssertEqual(t, len(expected), pq.Len())
+		last := 1 << 30
+		for pq.Len() > 0 {
+			k, p := pq.Pop()
+			AssertEqual(t, expected[k], p)
+			AssertTrue(t, p <= last)
+			last = p
+		}
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, MergeSorted([]int{1, 4, 7}, []int{2, 5, 8}, []int{3, 6, 9}))
+	AssertSliceEqual(t, []int{1, 1, 2, 2, 3}, MergeSorted([]int{1, 2}, []int{}, []int{1, 2, 3}))
+	AssertSliceEqual(t, []string{"a", "b", "c"}, MergeSorted([]string{"b"}, []string{"a", "c"}))
+	AssertSliceEqual(t, []float64{-1.5, 0, 2.5}, MergeSorted([]float64{-1.5, 2.5}, []float64{0}))
+	AssertSliceEqual(t, []int{}, MergeSorted[int]())
+	AssertSliceEqual(t, []int{}, MergeSorted([]int{}, []int{}))
+
+	// random
+	for i := 0; i < 100; i++ {
+		arrays := make([][]int, rand.Intn(10))
+		all := []int{}
+		for j := range arrays {
+			arrays[j] = make([]int, rand.Intn(20))
+			for k := range arrays[j] {
+				arrays[j][k] = rand.Intn(100)
+			}
+			sort.Ints(arrays[j])
+			all = append(all, arrays[j]...)
+		}
+		sort.Ints(all)
+		AssertSliceEqual(t, all, MergeSorted(arrays...))
+	}
+}
+
+func TestMergeSortedBy(t *testing.T) {
+	type Event struct {
+		name string
+		time int
+	}
+	shard1 := []Event{{"a", 1}, {"c", 5}, {"e", 5}}
+	shard2 := []Event{{"b", 3}, {"d", 5}}
+	shard3 := []Event{{"f", 5}}
+	res := MergeSortedBy(func(a, b Event) bool {
+		return a.time < b.time
+	}, shard1, shard2, shard3)
+	AssertSliceEqual(t, []Event{{"a", 1}, {"b", 3}, {"c", 5}, {"e", 5}, {"d", 5}, {"f", 5}}, res)
+
+	// descending order
+	AssertSliceEqual(t, []int{9, 5, 4, 1, 0}, MergeSortedBy(func(a, b int) bool {
+		return a > b
+	}, []int{9, 4, 0}, []int{5, 1}))
+}
@@ -0,0 +1,231 @@
+package gfn
+
+/* @example InnerJoin
+type User struct {
+	id   int
+	name string
+}
+type Order struct {
+	userID int
+	item   string
+}
+users := []User{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+orders := []Order{{1, "apple"}, {3, "banana"}, {1, "lemon"}, {4, "grape"}}
+gfn.InnerJoin(users, orders, func(u User) int {
+	return u.id
+}, func(o Order) int {
+	return o.userID
+})
+// []gfn.Pair[User, Order]{
+// 	{User{1, "Alice"}, Order{1, "apple"}},
+// 	{User{1, "Alice"}, Order{1, "lemon"}},
+// 	{User{3, "Carol"}, Order{3, "banana"}},
+// }
+*/
+
+// InnerJoin returns a pair for every left and right value with the same key,
+// using a hash join in O(n+m) time plus the size of the result. Pairs are
+// ordered by the left array, and pairs of the same left value by the right
+// array.
+func InnerJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []Pair[L, R] {
+	index := indexByKey(right, rightKey)
+	res := []Pair[L, R]{}
+	for _, l := range left {
+		for _, i := range index[leftKey(l)] {
+			res = append(res, Pair[L, R]{l, right[i]})
+		}
+	}
+	return res
+}
+
+/* @example LeftJoin
+type User struct {
+	id   int
+	name string
+}
+type Order struct {
+	userID int
+	item   string
+}
+users := []User{{1, "Alice"}, {2, "Bob"}}
+orders := []Order{{1, "apple"}, {1, "lemon"}}
+gfn.LeftJoin(users, orders, func(u User) int {
+	return u.id
+}, func(o Order) int {
+	return o.userID
+})
+// []gfn.Pair[User, *Order]{
+// 	{User{1, "Alice"}, &Order{1, "apple"}},
+// 	{User{1, "Alice"}, &Order{1, "lemon"}},
+// 	{User{2, "Bob"}, nil},
+// }
+*/
+
+// LeftJoin returns the pairs of InnerJoin, plus a pair with a nil right side
+// for every left value without a match, in the order of InnerJoin. The right
+// sides point to copies of the right values.
+func LeftJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []Pair[L, *R] {
+	index := indexByKey(right, rightKey)
+	res := []Pair[L, *R]{}
+	for _, l := range left {
+		matches := index[leftKey(l)]
+		if len(matches) == 0 {
+			res = append(res, Pair[L, *R]{l, nil})
+		}
+		for _, i := range matches {
+			r := right[i]
+			res = append(res, Pair[L, *R]{l, &r})
+		}
+	}
+	return res
+}
+
+/* @example RightJoin
+type User struct {
+	id   int
+	name string
+}
+type Order struct {
+	userID int
+	item   string
+}
+users := []User{{1, "Alice"}, {2, "Bob"}}
+orders := []Order{{1, "apple"}, {4, "grape"}}
+gfn.RightJoin(users, orders, func(u User) int {
+	return u.id
+}, func(o Order) int {
+	return o.userID
+})
+// []gfn.Pair[*User, Order]{
+// 	{&User{1, "Alice"}, Order{1, "apple"}},
+// 	{nil, Order{4, "grape"}},
+// }
+*/
+
+// RightJoin is the mirror of LeftJoin, it returns a pair for every left and
+// right value with the same key, plus a pair with a nil left side for every
+// right value without a match. Pairs are ordered by the right array, and
+// pairs of the same right value by the left array. The left sides point to
+// copies of the left values.
+func RightJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []Pair[*L, R] {
+	return Map(LeftJoin(right, left, rightKey, leftKey), func(p Pair[R, *L]) Pair[*L, R] {
+		return Pair[*L, R]{p.Second, p.First}
+	})
+}
+
+/* @example FullOuterJoin
+type User struct {
+	id   int
+	name string
+}
+type Order struct {
+	userID int
+	item   string
+}
+users := []User{{1, "Alice"}, {2, "Bob"}}
+orders := []Order{{1, "apple"}, {4, "grape"}}
+gfn.FullOuterJoin(users, orders, func(u User) int {
+	return u.id
+}, func(o Order) int {
+	return o.userID
+})
+// []gfn.Pair[*User, *Order]{
+// 	{&User{1, "Alice"}, &Order{1, "apple"}},
+// 	{&User{2, "Bob"}, nil},
+// 	{nil, &Order{4, "grape"}},
+// }
+*/
+
+// FullOuterJoin returns the pairs of LeftJoin in the same order, followed by
+// a pair with a nil left side for every right value without a match, in the
+// order of the right array. Both sides point to copies of the values.
+func FullOuterJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []Pair[*L, *R] {
+	res := Map(LeftJoin(left, right, leftKey, rightKey), func(p Pair[L, *R]) Pair[*L, *R] {
+		l := p.First
+		return Pair[*L, *R]{&l, p.Second}
+	})
+	leftKeys := make(map[K]struct{}, len(left))
+	for _, l := range left {
+		leftKeys[leftKey(l)] = struct{}{}
+	}
+	for _, r := range right {
+		if _, ok := leftKeys[rightKey(r)]; !ok {
+			r := r
+			res = append(res, Pair[*L, *R]{nil, &r})
+		}
+	}
+	return res
+}
+
+/* @example SemiJoin
+type User struct {
+	id   int
+	name string
+}
+type Order struct {
+	userID int
+	item   string
+}
+users := []User{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+orders := []Order{{1, "apple"}, {1, "lemon"}, {3, "banana"}}
+gfn.SemiJoin(users, orders, func(u User) int {
+	return u.id
+}, func(o Order) int {
+	return o.userID
+})  // []User{{1, "Alice"}, {3, "Carol"}}
+*/
+
+// SemiJoin returns the left values that have at least one right value with
+// the same key, in the order of the left array. Every left value is returned
+// at most once, no matter how many right values match it.
+func SemiJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []L {
+	keys := make(map[K]struct{}, len(right))
+	for _, r := range right {
+		keys[rightKey(r)] = struct{}{}
+	}
+	return Filter(left, func(l L) bool {
+		_, ok := keys[leftKey(l)]
+		return ok
+	})
+}
+
+/* @example AntiJoin
+type User struct {
+	id   int
+	name string
+}
+type Order struct {
+	userID int
+	item   string
+}
+users := []User{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+orders := []Order{{1, "apple"}, {1, "lemon"}, {3, "banana"}}
+gfn.AntiJoin(users, orders, func(u User) int {
+	return u.id
+}, func(o Order) int {
+	return o.userID
+})  // []User{{2, "Bob"}}
+*/
+
+// AntiJoin returns the left values that have no right value with the same
+// key, in the order of the left array.
+func AntiJoin[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) []L {
+	keys := make(map[K]struct{}, len(right))
+	for _, r := range right {
+		keys[rightKey(r)] = struct{}{}
+	}
+	return Filter(left, func(l L) bool {
+		_, ok := keys[leftKey(l)]
+		return !ok
+	})
+}
+
+// indexByKey returns the indexes of the values of every key, in ascending order.
+func indexByKey[T any, K comparable](array []T, key func(T) K) map[K][]int {
+	res := make(map[K][]int)
+	for i, v := range array {
+		k := key(v)
+		res[k] = append(res[k], i)
+	}
+	return res
+}
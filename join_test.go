@@ -0,0 +1,141 @@
+package gfn_test
+
+import (
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+type user struct {
+	id   int
+	name string
+}
+
+type order struct {
+	userID int
+	item   string
+}
+
+var (
+	joinUsers  = []user{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+	joinOrders = []order{{1, "apple"}, {3, "banana"}, {1, "lemon"}, {4, "grape"}}
+)
+
+func userID(u user) int {
+	return u.id
+}
+
+func orderUserID(o order) int {
+	return o.userID
+}
+
+// deref returns the value of p, or the zero value and false if p is nil.
+func deref[T any](p *T) Pair[T, bool] {
+	if p == nil {
+		var zero T
+		return Pair[T, bool]{zero, false}
+	}
+	return Pair[T, bool]{*p, true}
+}
+
+func TestInnerJoin(t *testing.T) {
+	AssertSliceEqual(t, []Pair[user, order]{
+		{user{1, "Alice"}, order{1, "apple"}},
+		{user{1, "Alice"}, order{1, "lemon"}},
+		{user{3, "Carol"}, order{3, "banana"}},
+	}, InnerJoin(joinUsers, joinOrders, userID, orderUserID))
+	AssertSliceEqual(t, []Pair[user, order]{}, InnerJoin(joinUsers, []order{}, userID, orderUserID))
+	AssertSliceEqual(t, []Pair[user, order]{}, InnerJoin([]user{}, joinOrders, userID, orderUserID))
+
+	// keys of different types can be joined by converting them
+	AssertSliceEqual(t, []Pair[int, string]{{1, "1"}, {1, "1"}}, InnerJoin([]int{1, 2}, []string{"1", "1"}, func(i int) string {
+		return string(rune('0' + i))
+	}, func(s string) string {
+		return s
+	}))
+}
+
+func TestLeftJoin(t *testing.T) {
+	res := LeftJoin(joinUsers, joinOrders, userID, orderUserID)
+	AssertSliceEqual(t, []user{{1, "Alice"}, {1, "Alice"}, {2, "Bob"}, {3, "Carol"}}, Map(res, func(p Pair[user, *order]) user {
+		return p.First
+	}))
+	AssertSliceEqual(t, []Pair[order, bool]{
+		{order{1, "apple"}, true},
+		{order{1, "lemon"}, true},
+		{order{}, false},
+		{order{3, "banana"}, true},
+	}, Map(res, func(p Pair[user, *order]) Pair[order, bool] {
+		return deref(p.Second)
+	}))
+
+	// right sides are copies
+	orders := []order{{1, "apple"}}
+	res = LeftJoin(joinUsers[:1], orders, userID, orderUserID)
+	res[0].Second.item = "changed"
+	AssertEqual(t, "apple", orders[0].item)
+
+	AssertEqual(t, 0, len(LeftJoin([]user{}, joinOrders, userID, orderUserID)))
+}
+
+func TestRightJoin(t *testing.T) {
+	res := RightJoin(joinUsers, joinOrders, userID, orderUserID)
+	AssertSliceEqual(t, joinOrders, Map(res, func(p Pair[*user, order]) order {
+		return p.Second
+	}))
+	AssertSliceEqual(t, []Pair[user, bool]{
+		{user{1, "Alice"}, true},
+		{user{3, "Carol"}, true},
+		{user{1, "Alice"}, true},
+		{user{}, false},
+	}, Map(res, func(p Pair[*user, order]) Pair[user, bool] {
+		return deref(p.First)
+	}))
+
+	// pairs of the same right value are ordered by the left array
+	res2 := RightJoin([]string{"b", "a"}, []int{1}, func(s string) int {
+		return 1
+	}, func(i int) int {
+		return i
+	})
+	AssertEqual(t, 2, len(res2))
+	AssertEqual(t, "b", *res2[0].First)
+	AssertEqual(t, "a", *res2[1].First)
+}
+
+func TestFullOuterJoin(t *testing.T) {
+	res := FullOuterJoin(joinUsers, joinOrders, userID, orderUserID)
+	AssertSliceEqual(t, []Pair[user, bool]{
+		{user{1, "Alice"}, true},
+		{user{1, "Alice"}, true},
+		{user{2, "Bob"}, true},
+		{user{3, "Carol"}, true},
+		{user{}, false},
+	}, Map(res, func(p Pair[*user, *order]) Pair[user, bool] {
+		return deref(p.First)
+	}))
+	AssertSliceEqual(t, []Pair[order, bool]{
+		{order{1, "apple"}, true},
+		{order{1, "lemon"}, true},
+		{order{}, false},
+		{order{3, "banana"}, true},
+		{order{4, "grape"}, true},
+	}, Map(res, func(p Pair[*user, *order]) Pair[order, bool] {
+		return deref(p.Second)
+	}))
+
+	AssertEqual(t, 0, len(FullOuterJoin([]user{}, []order{}, userID, orderUserID)))
+	AssertEqual(t, 4, len(FullOuterJoin([]user{}, joinOrders, userID, orderUserID)))
+}
+
+func TestSemiJoin(t *testing.T) {
+	AssertSliceEqual(t, []user{{1, "Alice"}, {3, "Carol"}}, SemiJoin(joinUsers, joinOrders, userID, orderUserID))
+	AssertSliceEqual(t, []user{}, SemiJoin(joinUsers, []order{}, userID, orderUserID))
+	AssertSliceEqual(t, []order{{1, "apple"}, {3, "banana"}, {1, "lemon"}}, SemiJoin(joinOrders, joinUsers, orderUserID, userID))
+}
+
+func TestAntiJoin(t *testing.T) {
+	AssertSliceEqual(t, []user{{2, "Bob"}}, AntiJoin(joinUsers, joinOrders, userID, orderUserID))
+	AssertSliceEqual(t, joinUsers, AntiJoin(joinUsers, []order{}, userID, orderUserID))
+	AssertSliceEqual(t, []order{{4, "grape"}}, AntiJoin(joinOrders, joinUsers, orderUserID, userID))
+}
@@ -1,5 +1,10 @@
 package gfn
 
+import (
+	"errors"
+	"fmt"
+)
+
 /* @example EqualKV
 map1 := map[int]struct{}{1: {}, 2: {}, 3: {}}
 map2 := map[int]struct{}{1: {}, 2: {}, 3: {}}
@@ -42,6 +47,22 @@ func EqualKVBy[K comparable, V1, V2 any](a map[K]V1, b map[K]V2, fn func(K, V1,
 	return true
 }
 
+/* @example EqualKVApprox
+a, b := 0.1, 0.2
+m1 := map[string]float64{"a": a + b, "b": math.NaN()}
+m2 := map[string]float64{"a": 0.3, "b": math.NaN()}
+gfn.EqualKVApprox(m1, m2, gfn.AbsTolerance(1e-9))                 // false
+gfn.EqualKVApprox(m1, m2, gfn.AbsTolerance(1e-9).WithNaNEqual())  // true
+*/
+
+// EqualKVApprox returns true if two maps have the same keys and their values
+// are approximately equal under the Tolerance, see AlmostEqual.
+func EqualKVApprox[K comparable, V Float](a, b map[K]V, tol Tolerance) bool {
+	return EqualKVBy(a, b, func(_ K, x, y V) bool {
+		return AlmostEqual(x, y, tol)
+	})
+}
+
 /* @example Keys
 gfn.Keys(map[int]string{1: "a", 2: "b", 3: "c"})
 // []int{1, 2, 3} or []int{3, 2, 1} or []int{2, 1, 3} etc.
@@ -346,3 +367,143 @@ func ToKV[K comparable, V any](n int, fn func(int) (K, V)) map[K]V {
 	}
 	return m
 }
+
+// ErrDuplicateKey is returned by AssociateWith with DuplicateError if two
+// elements have the same key.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// DuplicatePolicy decides which value AssociateWith keeps when two elements
+// have the same key.
+type DuplicatePolicy int
+
+const (
+	// DuplicateKeepLast keeps the value of the last element with the key.
+	DuplicateKeepLast DuplicatePolicy = iota
+	// DuplicateKeepFirst keeps the value of the first element with the key.
+	DuplicateKeepFirst
+	// DuplicateError stops at the first duplicate and returns ErrDuplicateKey.
+	DuplicateError
+)
+
+/* @example KeyBy
+type Employee struct {
+	id   int
+	name string
+}
+employees := []Employee{{1, "Alice"}, {2, "Bob"}}
+gfn.KeyBy(employees, func(e Employee) int {
+	return e.id
+})
+// map[int]Employee{1: {1, "Alice"}, 2: {2, "Bob"}}
+*/
+
+// KeyBy returns a map of the elements of an array, keyed by keyFn. If several
+// elements have the same key, the last one is kept, use AssociateWith for
+// other policies.
+func KeyBy[T any, K comparable](array []T, keyFn func(T) K) map[K]T {
+	return Associate(array, func(v T) (K, T) {
+		return keyFn(v), v
+	})
+}
+
+/* @example Associate
+type Employee struct {
+	id   int
+	name string
+}
+employees := []Employee{{1, "Alice"}, {2, "Bob"}}
+gfn.Associate(employees, func(e Employee) (int, string) {
+	return e.id, e.name
+})
+// map[int]string{1: "Alice", 2: "Bob"}
+*/
+
+// Associate returns a map of the keys and values returned by fn for every
+// element of an array. If several elements have the same key, the last value
+// is kept, use AssociateWith for other policies.
+func Associate[T any, K comparable, V any](array []T, fn func(T) (K, V)) map[K]V {
+	res, _ := AssociateWith(array, fn, DuplicateKeepLast)
+	return res
+}
+
+/* @example AssociateWith
+words := []string{"apple", "avocado", "banana"}
+gfn.AssociateWith(words, func(s string) (byte, string) {
+	return s[0], s
+}, gfn.DuplicateKeepFirst)
+// map[byte]string{'a': "apple", 'b': "banana"}, nil
+
+gfn.AssociateWith(words, func(s string) (byte, string) {
+	return s[0], s
+}, gfn.DuplicateError)
+// nil, error of duplicate key 97
+*/
+
+// AssociateWith returns a map of the keys and values returned by fn for every
+// element of an array, and handles elements with the same key according to
+// policy. For DuplicateError, it returns nil and an error wrapping
+// ErrDuplicateKey at the first duplicate, otherwise the error is always nil.
+// It panics if the policy is invalid.
+func AssociateWith[T any, K comparable, V any](array []T, fn func(T) (K, V), policy DuplicatePolicy) (map[K]V, error) {
+	if policy < DuplicateKeepLast || policy > DuplicateError {
+		panic("invalid duplicate policy")
+	}
+
+	res := make(map[K]V, len(array))
+	for _, v := range array {
+		k, value := fn(v)
+		if _, ok := res[k]; ok {
+			switch policy {
+			case DuplicateKeepLast:
+			case DuplicateKeepFirst:
+				continue
+			case DuplicateError:
+				return nil, fmt.Errorf("%w %v", ErrDuplicateKey, k)
+			}
+		}
+		res[k] = value
+	}
+	return res, nil
+}
+
+/* @example AssociateMerge
+type Order struct {
+	customer string
+	amount   int
+}
+orders := []Order{{"Alice", 10}, {"Bob", 20}, {"Alice", 5}}
+gfn.AssociateMerge(orders, func(o Order) (string, int) {
+	return o.customer, o.amount
+}, func(_ string, a, b int) int {
+	return a + b
+})
+// map[string]int{"Alice": 15, "Bob": 20}
+*/
+
+// AssociateMerge returns a map of the keys and values returned by fn for
+// every element of an array. If several elements have the same key, merge is
+// called with the key, the kept value and the new value, and its result is kept.
+func AssociateMerge[T any, K comparable, V any](array []T, fn func(T) (K, V), merge func(K, V, V) V) map[K]V {
+	res := make(map[K]V, len(array))
+	for _, v := range array {
+		k, value := fn(v)
+		if old, ok := res[k]; ok {
+			value = merge(k, old, value)
+		}
+		res[k] = value
+	}
+	return res
+}
+
+/* @example FromItems
+gfn.FromItems([]gfn.Pair[string, int]{{"a", 1}, {"b", 2}})
+// map[string]int{"a": 1, "b": 2}
+*/
+
+// FromItems converts pairs of keys and values to a map, which is the inverse
+// of Items. If several pairs have the same key, the last value is kept.
+func FromItems[K comparable, V any](items []Pair[K, V]) map[K]V {
+	return Associate(items, func(p Pair[K, V]) (K, V) {
+		return p.First, p.Second
+	})
+}
@@ -1,7 +1,9 @@
 package gfn_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"testing"
@@ -247,3 +249,101 @@ func TestToKV(t *testing.T) {
 		})
 	})
 }
+
+func TestEqualKVApprox(t *testing.T) {
+	a, b := 0.1, 0.2
+	m1 := map[string]float64{"a": a + b, "b": math.NaN()}
+	m2 := map[string]float64{"a": 0.3, "b": math.NaN()}
+	AssertFalse(t, EqualKVApprox(m1, m2, AbsTolerance(1e-9)))
+	AssertTrue(t, EqualKVApprox(m1, m2, AbsTolerance(1e-9).WithNaNEqual()))
+	AssertFalse(t, EqualKVApprox(m1, map[string]float64{"a": 0.3}, AbsTolerance(1e-9).WithNaNEqual()))
+	AssertFalse(t, EqualKVApprox(map[string]float64{"a": 1}, map[string]float64{"b": 1}, AbsTolerance(1)))
+	AssertTrue(t, EqualKVApprox(map[int]float32{}, map[int]float32{}, RelTolerance(0)))
+}
+
+func TestKeyBy(t *testing.T) {
+	type Employee struct {
+		id   int
+		name string
+	}
+	employees := []Employee{{1, "Alice"}, {2, "Bob"}, {1, "Alex"}}
+	AssertMapEqual(t, map[int]Employee{1: {1, "Alex"}, 2: {2, "Bob"}}, KeyBy(employees, func(e Employee) int {
+		return e.id
+	}))
+	AssertMapEqual(t, map[int]Employee{}, KeyBy([]Employee{}, func(e Employee) int {
+		return e.id
+	}))
+}
+
+func TestAssociate(t *testing.T) {
+	AssertMapEqual(t, map[int]string{1: "1", 2: "2"}, Associate([]int{1, 2}, func(i int) (int, string) {
+		return i, strconv.Itoa(i)
+	}))
+	AssertMapEqual(t, map[bool]int{false: 3, true: 4}, Associate([]int{1, 2, 3, 4}, func(i int) (bool, int) {
+		return i%2 == 0, i
+	}))
+}
+
+func TestAssociateWith(t *testing.T) {
+	words := []string{"apple", "avocado", "banana"}
+	firstLetter := func(s string) (byte, string) {
+		return s[0], s
+	}
+
+	m, err := AssociateWith(words, firstLetter, DuplicateKeepFirst)
+	AssertTrue(t, err == nil)
+	AssertMapEqual(t, map[byte]string{'a': "apple", 'b': "banana"}, m)
+
+	m, err = AssociateWith(words, firstLetter, DuplicateKeepLast)
+	AssertTrue(t, err == nil)
+	AssertMapEqual(t, map[byte]string{'a': "avocado", 'b': "banana"}, m)
+
+	m, err = AssociateWith(words, firstLetter, DuplicateError)
+	AssertTrue(t, m == nil)
+	AssertTrue(t, errors.Is(err, ErrDuplicateKey))
+	AssertEqual(t, "duplicate key 97", err.Error())
+
+	m, err = AssociateWith(words[1:], firstLetter, DuplicateError)
+	AssertTrue(t, err == nil)
+	AssertMapEqual(t, map[byte]string{'a': "avocado", 'b': "banana"}, m)
+
+	AssertPanics(t, func() {
+		_, _ = AssociateWith(words, firstLetter, DuplicatePolicy(10))
+	})
+	AssertPanics(t, func() {
+		_, _ = AssociateWith(words[1:], firstLetter, DuplicatePolicy(10))
+	})
+	AssertPanics(t, func() {
+		_, _ = AssociateWith([]string{}, firstLetter, DuplicatePolicy(-1))
+	})
+}
+
+func TestAssociateMerge(t *testing.T) {
+	type Order struct {
+		customer string
+		amount   int
+	}
+	orders := []Order{{"Alice", 10}, {"Bob", 20}, {"Alice", 5}}
+	AssertMapEqual(t, map[string]int{"Alice": 15, "Bob": 20}, AssociateMerge(orders, func(o Order) (string, int) {
+		return o.customer, o.amount
+	}, func(_ string, a, b int) int {
+		return a + b
+	}))
+
+	// merge is called in the order of the array
+	AssertMapEqual(t, map[int]string{0: "a,c", 1: "b"}, AssociateMerge([]string{"a", "b", "c"}, func(s string) (int, string) {
+		return int(s[0]-'a') % 2, s
+	}, func(_ int, a, b string) string {
+		return a + "," + b
+	}))
+}
+
+func TestFromItems(t *testing.T) {
+	AssertMapEqual(t, map[string]int{"a": 1, "b": 2}, FromItems([]Pair[string, int]{{"a", 1}, {"b", 2}}))
+	AssertMapEqual(t, map[string]int{"a": 3}, FromItems([]Pair[string, int]{{"a", 1}, {"a", 3}}))
+	AssertMapEqual(t, map[string]int{}, FromItems([]Pair[string, int]{}))
+
+	// inverse of Items
+	m := map[int]string{1: "a", 2: "b", 3: "c"}
+	AssertMapEqual(t, m, FromItems(Items(m)))
+}
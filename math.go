@@ -1,5 +1,11 @@
 package gfn
 
+import (
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
 /* @example Max
 gfn.Max([]int16{1, 5, 9, 10}...)  // 10
 gfn.Max("ab", "cd", "e")          // "e"
@@ -9,6 +15,7 @@ gfn.Max([]float64{math.NaN(), math.NaN(), math.NaN()}...) // NaN
 */
 
 // Max returns the maximum value in the array. For float64 arrays, NaN values are skipped.
+// Complex values have no natural order and are not accepted, see Complex.
 func Max[T Int | Uint | Float | ~string](array ...T) T {
 	if len(array) == 0 {
 		panic("array is empty")
@@ -32,6 +39,31 @@ func isNaN[T Int | Uint | Float | ~string](x T) bool {
 	return x != x
 }
 
+/* @example ArgMax
+gfn.ArgMax(1, 5, 9, 9, 2)               // 2
+gfn.ArgMax(math.NaN(), 1.5, 0.5)        // 1
+*/
+
+// ArgMax returns the index of the maximum value in the array. For float64
+// arrays, NaN values are skipped like Max. If several values share the
+// maximum, the first index is returned. If all values are NaN, 0 is returned.
+func ArgMax[T Int | Uint | Float | ~string](array ...T) int {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := 0
+	for i, v := range array {
+		if isNaN(v) {
+			continue
+		}
+		if isNaN(array[res]) || v > array[res] {
+			res = i
+		}
+	}
+	return res
+}
+
 /* @example MaxBy
 type Product struct {
 	name   string
@@ -48,6 +80,7 @@ p := gfn.MaxBy(products, func(p Product) int {
 */
 
 // MaxBy returns the maximum value in the array, using the given function to transform values.
+// If several values share the maximum, the first one is returned, use MaxAllBy to get all of them.
 func MaxBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) T {
 	if len(array) == 0 {
 		panic("array is empty")
@@ -65,6 +98,35 @@ func MaxBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) T {
 	return res
 }
 
+/* @example ArgMaxBy
+names := []string{"alice", "bob", "carol"}
+scores := []int{80, 95, 95}
+i := gfn.ArgMaxBy(scores, func(s int) int {
+	return s
+})
+names[i]  // "bob"
+*/
+
+// ArgMaxBy returns the index of the maximum value in the array, using the
+// given function to transform values. If several values share the maximum,
+// the first index is returned.
+func ArgMaxBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) int {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := 0
+	value := fn(array[0])
+	for i, v := range array[1:] {
+		current := fn(v)
+		if current > value {
+			res = i + 1
+			value = current
+		}
+	}
+	return res
+}
+
 /* @example Min
 gfn.Min(1.1, 2.2, 3.3)            // 1.1
 gfn.Min([]int16{1, 5, 9, 10}...)  // 1
@@ -74,6 +136,7 @@ gfn.Min([]float64{1.1, math.Inf(-1), math.NaN()}...) // math.Inf(-1)
 */
 
 // Min returns the minimum value in the array. For float64 arrays, NaN values are skipped.
+// Complex values have no natural order and are not accepted, see Complex.
 func Min[T Int | Uint | Float | ~string](array ...T) T {
 	if len(array) == 0 {
 		panic("array is empty")
@@ -91,6 +154,31 @@ func Min[T Int | Uint | Float | ~string](array ...T) T {
 	return res
 }
 
+/* @example ArgMin
+gfn.ArgMin(3, 1, 9, 1)                  // 1
+gfn.ArgMin(math.NaN(), 1.5, 0.5)        // 2
+*/
+
+// ArgMin returns the index of the minimum value in the array. For float64
+// arrays, NaN values are skipped like Min. If several values share the
+// minimum, the first index is returned. If all values are NaN, 0 is returned.
+func ArgMin[T Int | Uint | Float | ~string](array ...T) int {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := 0
+	for i, v := range array {
+		if isNaN(v) {
+			continue
+		}
+		if isNaN(array[res]) || v < array[res] {
+			res = i
+		}
+	}
+	return res
+}
+
 /* @example MinBy
 type Product struct {
 	name   string
@@ -107,6 +195,7 @@ p := gfn.MinBy(products, func(p Product) int {
 */
 
 // MinBy returns the minimum value in the array, using the given function to transform values.
+// If several values share the minimum, the first one is returned, use MinAllBy to get all of them.
 func MinBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) T {
 	if len(array) == 0 {
 		panic("array is empty")
@@ -124,6 +213,32 @@ func MinBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) T {
 	return res
 }
 
+/* @example ArgMinBy
+gfn.ArgMinBy([]string{"abc", "d", "ef", "g"}, func(s string) int {
+	return len(s)
+})  // 1
+*/
+
+// ArgMinBy returns the index of the minimum value in the array, using the
+// given function to transform values. If several values share the minimum,
+// the first index is returned.
+func ArgMinBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) int {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := 0
+	value := fn(array[0])
+	for i, v := range array[1:] {
+		current := fn(v)
+		if current < value {
+			res = i + 1
+			value = current
+		}
+	}
+	return res
+}
+
 /* @example Sum
 gfn.Sum([]int{1, 5, 9, 10}...)  // 25
 gfn.Sum(1.1, 2.2, 3.3)          // 6.6
@@ -187,6 +302,16 @@ func Abs[T Int | Float](x T) T {
 	return x
 }
 
+/* @example AbsComplex
+gfn.AbsComplex(3 + 4i)  // 5
+gfn.MaxBy([]complex128{1 + 1i, -3, 2i}, gfn.AbsComplex[complex128])  // -3
+*/
+
+// AbsComplex returns the absolute value (magnitude) of x.
+func AbsComplex[T Complex](x T) float64 {
+	return cmplx.Abs(complex128(x))
+}
+
 /* @example DivMod
 gfn.DivMod(10, 3) // (3, 1)
 */
@@ -196,6 +321,331 @@ func DivMod[T Int | Uint](a, b T) (T, T) {
 	return a / b, a % b
 }
 
+/* @example AddChecked
+gfn.AddChecked(1, 2)                 // 3, true
+gfn.AddChecked[int8](127, 1)         // -128, false
+gfn.AddChecked[uint8](255, 1)        // 0, false
+*/
+
+// AddChecked returns a+b and true, or the wrapped result and false if the
+// addition overflows.
+func AddChecked[T Int | Uint](a, b T) (T, bool) {
+	res := a + b
+	if b >= 0 {
+		return res, res >= a
+	}
+	return res, res < a
+}
+
+/* @example SubChecked
+gfn.SubChecked(3, 2)                 // 1, true
+gfn.SubChecked[int8](-128, 1)        // 127, false
+gfn.SubChecked[uint](0, 1)           // math.MaxUint, false
+*/
+
+// SubChecked returns a-b and true, or the wrapped result and false if the
+// subtraction overflows.
+func SubChecked[T Int | Uint](a, b T) (T, bool) {
+	res := a - b
+	if b >= 0 {
+		return res, res <= a
+	}
+	return res, res > a
+}
+
+/* @example MulChecked
+gfn.MulChecked(3, 4)                 // 12, true
+gfn.MulChecked[int8](64, 2)          // -128, false
+gfn.MulChecked[int8](-128, -1)       // -128, false
+*/
+
+// MulChecked returns a*b and true, or the wrapped result and false if the
+// multiplication overflows.
+func MulChecked[T Int | Uint](a, b T) (T, bool) {
+	res := a * b
+	if a == 0 || b == 0 {
+		return res, true
+	}
+	var zero T
+	if minusOne := zero - 1; isSigned[T]() && (a == minusOne || b == minusOne) {
+		// -1 * min overflows, but the division below cannot detect it
+		// because min / -1 overflows too
+		minimum := minInteger[T]()
+		return res, a != minimum && b != minimum
+	}
+	return res, res/b == a
+}
+
+/* @example AbsChecked
+gfn.AbsChecked(-1)                   // 1, true
+gfn.AbsChecked[int8](-128)           // -128, false
+*/
+
+// AbsChecked returns the absolute value of x and true, or x and false if x
+// is the minimum value of a signed type, whose absolute value overflows.
+func AbsChecked[T Int | Uint](x T) (T, bool) {
+	if x >= 0 {
+		return x, true
+	}
+	if x == minInteger[T]() {
+		return x, false
+	}
+	return -x, true
+}
+
+/* @example DivModChecked
+gfn.DivModChecked(10, 3)             // 3, 1, true
+gfn.DivModChecked(10, 0)             // 0, 0, false
+gfn.DivModChecked[int8](-128, -1)    // -128, 0, false
+*/
+
+// DivModChecked returns quotient and remainder of a/b and true. Instead of
+// panicking or overflowing, it returns false if b is zero or the quotient
+// overflows.
+func DivModChecked[T Int | Uint](a, b T) (T, T, bool) {
+	if b == 0 {
+		return 0, 0, false
+	}
+	var zero T
+	if isSigned[T]() && a == minInteger[T]() && b == zero-1 {
+		return a, 0, false
+	}
+	return a / b, a % b, true
+}
+
+/* @example SumChecked
+gfn.SumChecked(1, 2, 3)                      // 6, true
+gfn.SumChecked([]int8{100, 100, -100}...)    // 100, false
+*/
+
+// SumChecked returns the sum of all values in the array and true, or the
+// wrapped result and false if any intermediate sum overflows.
+func SumChecked[T Int | Uint](array ...T) (T, bool) {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := array[0]
+	ok := true
+	for _, v := range array[1:] {
+		var current bool
+		res, current = AddChecked(res, v)
+		ok = ok && current
+	}
+	return res, ok
+}
+
+/* @example ProductChecked
+gfn.ProductChecked(1, 2, 3)               // 6, true
+gfn.ProductChecked([]int8{16, 16}...)     // 0, false
+*/
+
+// ProductChecked returns the product of all values in the array and true,
+// or the wrapped result and false if any intermediate product overflows.
+func ProductChecked[T Int | Uint](array ...T) (T, bool) {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := array[0]
+	ok := true
+	for _, v := range array[1:] {
+		var current bool
+		res, current = MulChecked(res, v)
+		ok = ok && current
+	}
+	return res, ok
+}
+
+/* @example SaturatingAdd
+gfn.SaturatingAdd[int8](100, 100)    // 127
+gfn.SaturatingAdd[int8](-100, -100)  // -128
+gfn.SaturatingAdd[uint8](200, 100)   // 255
+*/
+
+// SaturatingAdd returns a+b, clamped to the minimum or maximum value of T on overflow.
+func SaturatingAdd[T Int | Uint](a, b T) T {
+	res, ok := AddChecked(a, b)
+	if ok {
+		return res
+	}
+	if b > 0 {
+		return maxInteger[T]()
+	}
+	return minInteger[T]()
+}
+
+/* @example SaturatingSub
+gfn.SaturatingSub[int8](-100, 100)   // -128
+gfn.SaturatingSub[uint8](1, 2)       // 0
+*/
+
+// SaturatingSub returns a-b, clamped to the minimum or maximum value of T on overflow.
+func SaturatingSub[T Int | Uint](a, b T) T {
+	res, ok := SubChecked(a, b)
+	if ok {
+		return res
+	}
+	if b > 0 {
+		return minInteger[T]()
+	}
+	return maxInteger[T]()
+}
+
+/* @example SaturatingMul
+gfn.SaturatingMul[int8](100, 2)      // 127
+gfn.SaturatingMul[int8](-100, 2)     // -128
+gfn.SaturatingMul[uint8](16, 16)     // 255
+*/
+
+// SaturatingMul returns a*b, clamped to the minimum or maximum value of T on overflow.
+func SaturatingMul[T Int | Uint](a, b T) T {
+	res, ok := MulChecked(a, b)
+	if ok {
+		return res
+	}
+	if (a < 0) != (b < 0) {
+		return minInteger[T]()
+	}
+	return maxInteger[T]()
+}
+
+// isSigned reports whether T is a signed integer type.
+func isSigned[T Int | Uint]() bool {
+	var zero T
+	return zero-1 < zero
+}
+
+// maxInteger returns the maximum value of integer type T.
+func maxInteger[T Int | Uint]() T {
+	return ^minInteger[T]()
+}
+
+// minInteger returns the minimum value of integer type T.
+func minInteger[T Int | Uint]() T {
+	if !isSigned[T]() {
+		return 0
+	}
+	// only the sign bit is set in the minimum value, so shifting 1 to the
+	// sign bit of each possible size of 8, 16, 32 or 64 bits finds it
+	shift := 7
+	for shift < 63 && T(1)<<shift > 0 {
+		shift = 2*shift + 1
+	}
+	return T(1) << shift
+}
+
+/* @example CumSum
+gfn.CumSum(1, 2, 3, 4)           // []int{1, 3, 6, 10}
+gfn.CumSum([]float64{0.5, 1}...)  // []float64{0.5, 1.5}
+*/
+
+// CumSum returns the cumulative sum of the array, the i-th element is the
+// sum of the first i+1 values.
+func CumSum[T Int | Uint | Float | Complex](array ...T) []T {
+	return Scan(array, 0, func(acc, v T) T {
+		return acc + v
+	})
+}
+
+/* @example CumProd
+gfn.CumProd(1, 2, 3, 4)  // []int{1, 2, 6, 24}
+*/
+
+// CumProd returns the cumulative product of the array, the i-th element is
+// the product of the first i+1 values.
+func CumProd[T Int | Uint | Float | Complex](array ...T) []T {
+	return Scan(array, 1, func(acc, v T) T {
+		return acc * v
+	})
+}
+
+/* @example CumMax
+gfn.CumMax(1, 3, 2, 5, 4)                 // []int{1, 3, 3, 5, 5}
+gfn.CumMax(math.NaN(), 1.0, math.NaN())   // []float64{NaN, 1, 1}
+*/
+
+// CumMax returns the cumulative maximum of the array, the i-th element is
+// the maximum of the first i+1 values. Like Max, NaN values are skipped.
+func CumMax[T Int | Uint | Float | ~string](array ...T) []T {
+	res := make([]T, len(array))
+	for i, v := range array {
+		if i == 0 || isNaN(res[i-1]) || v > res[i-1] {
+			res[i] = v
+		} else {
+			res[i] = res[i-1]
+		}
+	}
+	return res
+}
+
+/* @example CumMin
+gfn.CumMin(5, 3, 4, 1, 2)  // []int{5, 3, 3, 1, 1}
+*/
+
+// CumMin returns the cumulative minimum of the array, the i-th element is
+// the minimum of the first i+1 values. Like Min, NaN values are skipped.
+func CumMin[T Int | Uint | Float | ~string](array ...T) []T {
+	res := make([]T, len(array))
+	for i, v := range array {
+		if i == 0 || isNaN(res[i-1]) || v < res[i-1] {
+			res[i] = v
+		} else {
+			res[i] = res[i-1]
+		}
+	}
+	return res
+}
+
+/* @example Diff
+gfn.Diff([]int{1, 3, 6, 10}, 1)  // []int{2, 3, 4}
+gfn.Diff([]int{1, 3, 6, 10}, 2)  // []int{1, 1}
+*/
+
+// Diff returns the n-th order discrete difference of the array. The first
+// order difference is array[i+1] - array[i], higher orders are calculated
+// recursively. The result has max(len(array)-n, 0) elements, n = 0 returns
+// a copy of the array and negative n panics. For unsigned types, a negative
+// difference wraps around.
+func Diff[T Int | Uint | Float | Complex](array []T, n int) []T {
+	if n < 0 {
+		panic("order must be greater or equal to 0")
+	}
+	if n >= len(array) {
+		return []T{}
+	}
+
+	res := Copy(array)
+	for ; n > 0; n-- {
+		for i := 0; i < len(res)-1; i++ {
+			res[i] = res[i+1] - res[i]
+		}
+		res = res[:len(res)-1]
+	}
+	return res
+}
+
+/* @example PercentChange
+gfn.PercentChange(100, 110, 99)  // []float64{0.1, -0.1}
+*/
+
+// PercentChange returns the fractional change between consecutive values,
+// (array[i+1] - array[i]) / array[i], so 0.1 means an increase of 10%. The
+// result has one less element than the array. A change from zero produces
+// Inf, or NaN if both values are zero.
+func PercentChange[T Int | Uint | Float](array ...T) []float64 {
+	if len(array) <= 1 {
+		return []float64{}
+	}
+
+	res := make([]float64, len(array)-1)
+	for i := range res {
+		prev, current := float64(array[i]), float64(array[i+1])
+		res[i] = (current - prev) / prev
+	}
+	return res
+}
+
 /* @example Mean
 gfn.Mean(1, 2, 3)               // 2.0
 gfn.Mean([]int{1, 2, 3, 4}...)  // 2.5
@@ -313,11 +763,93 @@ func MinMaxBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) (T
 	return minimum, maximum
 }
 
+/* @example MaxFunc
+type Version struct {
+	parts []int
+}
+versions := []Version{{[]int{1, 2}}, {[]int{1, 10}}, {[]int{1, 3, 1}}}
+gfn.MaxFunc(versions, func(a, b Version) bool {
+	for i := 0; i < len(a.parts) && i < len(b.parts); i++ {
+		if a.parts[i] != b.parts[i] {
+			return a.parts[i] < b.parts[i]
+		}
+	}
+	return len(a.parts) < len(b.parts)
+}) // {[]int{1, 10}}
+*/
+
+// MaxFunc returns the maximum value in the array, using less to compare
+// values. less should return true if a is less than b. If several values
+// share the maximum, the first one is returned.
+func MaxFunc[T any](array []T, less func(a, b T) bool) T {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := array[0]
+	for _, v := range array[1:] {
+		if less(res, v) {
+			res = v
+		}
+	}
+	return res
+}
+
+/* @example MinFunc
+gfn.MinFunc([][]int{{3, 1}, {2}, {2, 5}}, func(a, b []int) bool {
+	return gfn.Sum(a...) < gfn.Sum(b...)
+}) // []int{2}
+*/
+
+// MinFunc returns the minimum value in the array, using less to compare
+// values. less should return true if a is less than b. If several values
+// share the minimum, the first one is returned.
+func MinFunc[T any](array []T, less func(a, b T) bool) T {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := array[0]
+	for _, v := range array[1:] {
+		if less(v, res) {
+			res = v
+		}
+	}
+	return res
+}
+
+/* @example MinMaxFunc
+gfn.MinMaxFunc([][]int{{3, 1}, {2}, {2, 5}}, func(a, b []int) bool {
+	return gfn.Sum(a...) < gfn.Sum(b...)
+}) // []int{2}, []int{2, 5}
+*/
+
+// MinMaxFunc returns the minimum and maximum value in the array, using less
+// to compare values, like MinFunc and MaxFunc.
+func MinMaxFunc[T any](array []T, less func(a, b T) bool) (T, T) {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	minimum, maximum := array[0], array[0]
+	for _, v := range array[1:] {
+		if less(v, minimum) {
+			minimum = v
+		}
+		if less(maximum, v) {
+			maximum = v
+		}
+	}
+	return minimum, maximum
+}
+
 /* @example Mode
 gfn.Mode([]int{1, 1, 5, 5, 5, 2, 2})) // 5
 */
 
-// Mode returns the most frequent value in the array.
+// Mode returns the most frequent value in the array. If several values are
+// tied, the one that first reaches the highest count while scanning the array
+// is returned, use MultiMode to get all of them.
 func Mode[T comparable](array []T) T {
 	if len(array) == 0 {
 		panic("array is empty")
@@ -353,6 +885,8 @@ gfn.ModeBy(products, func(p Product) int {
 */
 
 // ModeBy returns the most frequent value in the array, using the given function to transform values.
+// If several keys are tied, it returns the element at which a key first reaches
+// the highest count while scanning the array, use MultiModeBy to get all of them.
 func ModeBy[T any, U comparable](array []T, fn func(T) U) T {
 	if len(array) == 0 {
 		panic("array is empty")
@@ -372,3 +906,690 @@ func ModeBy[T any, U comparable](array []T, fn func(T) U) T {
 	}
 	return value
 }
+
+/* @example MultiMode
+gfn.MultiMode([]int{1, 1, 5, 5, 2})     // []int{1, 5}
+gfn.MultiMode([]string{"a", "b", "c"})  // []string{"a", "b", "c"}
+*/
+
+// MultiMode returns all values tied for the most frequent in the array, in
+// the order of their first occurrence.
+func MultiMode[T comparable](array []T) []T {
+	return Map(ArgMultiMode(array), func(i int) T {
+		return array[i]
+	})
+}
+
+/* @example ArgMultiMode
+gfn.ArgMultiMode([]int{2, 1, 1, 5, 5})  // []int{1, 3}
+*/
+
+// ArgMultiMode returns the index of the first occurrence of every value tied
+// for the most frequent in the array, in ascending order.
+func ArgMultiMode[T comparable](array []T) []int {
+	return argMultiMode(array, func(v T) T { return v })
+}
+
+/* @example MultiModeBy
+type Product struct {
+	name   string
+	amount int
+}
+products := []Product{
+	{"apple", 10},
+	{"banana", 20},
+	{"orange", 10},
+	{"grape", 20},
+	{"lemon", 30},
+}
+gfn.MultiModeBy(products, func(p Product) int {
+	return p.amount
+}) // []Product{{"apple", 10}, {"banana", 20}}
+*/
+
+// MultiModeBy returns the first element of every key tied for the most
+// frequent, using the given function to transform values. The elements are
+// in the order of their first occurrence.
+func MultiModeBy[T any, U comparable](array []T, fn func(T) U) []T {
+	return Map(argMultiMode(array, fn), func(i int) T {
+		return array[i]
+	})
+}
+
+func argMultiMode[T any, U comparable](array []T, fn func(T) U) []int {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	keys := Map(array, fn)
+	counter := Counter(keys)
+	maxCount := Max(Values(counter)...)
+	res := []int{}
+	for i, k := range keys {
+		if counter[k] == maxCount {
+			res = append(res, i)
+			// only the first occurrence is returned
+			counter[k] = 0
+		}
+	}
+	return res
+}
+
+/* @example MaxAllBy
+type Product struct {
+	name   string
+	amount int
+}
+products := []Product{
+	{"apple", 10},
+	{"banana", 30},
+	{"orange", 30},
+}
+gfn.MaxAllBy(products, func(p Product) int {
+	return p.amount
+}) // []Product{{"banana", 30}, {"orange", 30}}
+*/
+
+// MaxAllBy returns all values sharing the maximum in the array, using the
+// given function to transform values. The values keep their order in the array.
+func MaxAllBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) []T {
+	return Map(ArgMaxAllBy(array, fn), func(i int) T {
+		return array[i]
+	})
+}
+
+/* @example ArgMaxAllBy
+gfn.ArgMaxAllBy([]string{"ab", "c", "de"}, func(s string) int {
+	return len(s)
+}) // []int{0, 2}
+*/
+
+// ArgMaxAllBy returns the indexes of all values sharing the maximum in the
+// array in ascending order, using the given function to transform values.
+func ArgMaxAllBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) []int {
+	return argExtremeAllBy(array, fn, func(a, b U) bool { return a > b })
+}
+
+/* @example MinAllBy
+type Product struct {
+	name   string
+	amount int
+}
+products := []Product{
+	{"apple", 10},
+	{"banana", 30},
+	{"orange", 10},
+}
+gfn.MinAllBy(products, func(p Product) int {
+	return p.amount
+}) // []Product{{"apple", 10}, {"orange", 10}}
+*/
+
+// MinAllBy returns all values sharing the minimum in the array, using the
+// given function to transform values. The values keep their order in the array.
+func MinAllBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) []T {
+	return Map(ArgMinAllBy(array, fn), func(i int) T {
+		return array[i]
+	})
+}
+
+/* @example ArgMinAllBy
+gfn.ArgMinAllBy([]string{"ab", "c", "d"}, func(s string) int {
+	return len(s)
+}) // []int{1, 2}
+*/
+
+// ArgMinAllBy returns the indexes of all values sharing the minimum in the
+// array in ascending order, using the given function to transform values.
+func ArgMinAllBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U) []int {
+	return argExtremeAllBy(array, fn, func(a, b U) bool { return a < b })
+}
+
+// argExtremeAllBy returns the indexes of all values whose key is not beaten
+// by any other key, where better reports whether a beats b.
+func argExtremeAllBy[T any, U Int | Uint | Float | ~string](array []T, fn func(T) U, better func(a, b U) bool) []int {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := []int{0}
+	value := fn(array[0])
+	for i, v := range array[1:] {
+		current := fn(v)
+		if better(current, value) {
+			res = res[:0]
+			value = current
+		}
+		if current == value {
+			res = append(res, i+1)
+		}
+	}
+	return res
+}
+
+/* @example Dot
+gfn.Dot([]float64{1, 2, 3}, []float64{4, 5, 6})           // 32
+gfn.Dot([]float64{1, math.NaN(), 3}, []float64{4, 5, 6})  // 22
+*/
+
+// Dot returns the dot product of two arrays of the same length. Pairs
+// containing NaN are skipped, like NaN values are skipped by Max and Min.
+func Dot[T Float](a, b []T) T {
+	checkSameLength(a, b)
+	var res T
+	for i := range a {
+		if isNaN(a[i]) || isNaN(b[i]) {
+			continue
+		}
+		res += a[i] * b[i]
+	}
+	return res
+}
+
+// NormOrder is the kind of vector norm calculated by Norm.
+type NormOrder int
+
+const (
+	// L1Norm is the sum of absolute values.
+	L1Norm NormOrder = iota
+	// L2Norm is the Euclidean length, the square root of the sum of squares.
+	L2Norm
+	// InfNorm is the maximum absolute value.
+	InfNorm
+)
+
+/* @example Norm
+gfn.Norm([]float64{3, -4}, gfn.L1Norm)   // 7
+gfn.Norm([]float64{3, -4}, gfn.L2Norm)   // 5
+gfn.Norm([]float64{3, -4}, gfn.InfNorm)  // 4
+*/
+
+// Norm returns the norm of the array in the given order. NaN values are
+// skipped and the norm of an empty array is 0.
+func Norm[T Float](array []T, order NormOrder) T {
+	if order < L1Norm || order > InfNorm {
+		panic("invalid norm order")
+	}
+
+	var res T
+	for _, v := range array {
+		if isNaN(v) {
+			continue
+		}
+		switch order {
+		case L1Norm:
+			res += Abs(v)
+		case L2Norm:
+			res += v * v
+		case InfNorm:
+			if Abs(v) > res {
+				res = Abs(v)
+			}
+		}
+	}
+	if order == L2Norm {
+		res = T(math.Sqrt(float64(res)))
+	}
+	return res
+}
+
+/* @example Add
+gfn.Add([]float64{1, 2, 3}, []float64{4, 5, 6})  // []float64{5, 7, 9}
+*/
+
+// Add returns the element-wise sum of two arrays of the same length.
+func Add[T Float](a, b []T) []T {
+	return elementWise(a, b, func(x, y T) T { return x + y })
+}
+
+/* @example Sub
+gfn.Sub([]float64{1, 2, 3}, []float64{4, 5, 6})  // []float64{-3, -3, -3}
+*/
+
+// Sub returns the element-wise difference of two arrays of the same length.
+func Sub[T Float](a, b []T) []T {
+	return elementWise(a, b, func(x, y T) T { return x - y })
+}
+
+/* @example Mul
+gfn.Mul([]float64{1, 2, 3}, []float64{4, 5, 6})  // []float64{4, 10, 18}
+*/
+
+// Mul returns the element-wise product of two arrays of the same length.
+func Mul[T Float](a, b []T) []T {
+	return elementWise(a, b, func(x, y T) T { return x * y })
+}
+
+/* @example Div
+gfn.Div([]float64{1, 2, 3}, []float64{4, 5, 0})  // []float64{0.25, 0.4, +Inf}
+*/
+
+// Div returns the element-wise quotient of two arrays of the same length.
+// Division by zero follows IEEE 754 and produces Inf or NaN.
+func Div[T Float](a, b []T) []T {
+	return elementWise(a, b, func(x, y T) T { return x / y })
+}
+
+/* @example Scale
+gfn.Scale([]float64{1, 2, 3}, 2)  // []float64{2, 4, 6}
+*/
+
+// Scale returns a new array with every value multiplied by factor.
+func Scale[T Float](array []T, factor T) []T {
+	return Map(array, func(v T) T {
+		return v * factor
+	})
+}
+
+func elementWise[T Float](a, b []T, op func(x, y T) T) []T {
+	checkSameLength(a, b)
+	res := make([]T, len(a))
+	for i := range a {
+		res[i] = op(a[i], b[i])
+	}
+	return res
+}
+
+func checkSameLength[T, U any](a []T, b []U) {
+	if len(a) != len(b) {
+		panic("arrays must have the same length")
+	}
+}
+
+/* @example CosineSimilarity
+gfn.CosineSimilarity([]float64{1, 0}, []float64{1, 1})   // 0.7071067811865475
+gfn.CosineSimilarity([]float64{1, 2}, []float64{-2, -4}) // -1
+*/
+
+// CosineSimilarity returns the cosine of the angle between two arrays of the
+// same length. Pairs containing NaN are skipped like Dot. If either array is
+// a zero vector, 0 is returned.
+func CosineSimilarity[T Float](a, b []T) T {
+	checkSameLength(a, b)
+	var dot, normA, normB T
+	for i := range a {
+		if isNaN(a[i]) || isNaN(b[i]) {
+			continue
+		}
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return T(float64(dot) / (math.Sqrt(float64(normA)) * math.Sqrt(float64(normB))))
+}
+
+/* @example Normalize
+gfn.Normalize([]float64{3, -4}, gfn.L2Norm)  // []float64{0.6, -0.8}
+gfn.Normalize([]float64{3, -4}, gfn.L1Norm)  // []float64{3.0 / 7, -4.0 / 7}
+*/
+
+// Normalize returns a new array divided by its norm in the given order, so
+// that the result has a norm of 1. NaN values are skipped when calculating
+// the norm and stay NaN. A zero vector is returned unchanged.
+func Normalize[T Float](array []T, order NormOrder) []T {
+	norm := Norm(array, order)
+	if norm == 0 {
+		return Copy(array)
+	}
+	return Scale(array, 1/norm)
+}
+
+/* @example Standardize
+gfn.Standardize([]float64{1, 2, 3, 4})  // []float64{-1.3416, -0.4472, 0.4472, 1.3416}
+*/
+
+// Standardize returns a new array with the mean subtracted and divided by
+// the population standard deviation, so that the result has a mean of 0 and
+// a standard deviation of 1. NaN values are skipped when calculating the
+// mean and the standard deviation and stay NaN. If all values are equal,
+// every value becomes 0.
+func Standardize[T Float](array []T) []T {
+	values := floatValues(array)
+	if len(values) == 0 {
+		return Copy(array)
+	}
+
+	mean := Mean(values...)
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(variance / float64(len(values)))
+	return Map(array, func(v T) T {
+		if std == 0 && !isNaN(v) {
+			return 0
+		}
+		return T((float64(v) - mean) / std)
+	})
+}
+
+/* @example MinMaxScale
+gfn.MinMaxScale([]float64{1, 2, 3, 5})  // []float64{0, 0.25, 0.5, 1}
+*/
+
+// MinMaxScale returns a new array linearly scaled so that the minimum becomes
+// 0 and the maximum becomes 1. NaN values are skipped like Min and Max and
+// stay NaN. If all values are equal, every value becomes 0.
+func MinMaxScale[T Float](array []T) []T {
+	if len(array) == 0 {
+		return []T{}
+	}
+
+	minimum, maximum := MinMax(array...)
+	return Map(array, func(v T) T {
+		if minimum == maximum && !isNaN(v) {
+			return 0
+		}
+		return (v - minimum) / (maximum - minimum)
+	})
+}
+
+/* @example MeanComplex
+gfn.MeanComplex(1+2i, 3+4i)  // 2+3i
+*/
+
+// MeanComplex returns the mean of all complex values in the array.
+func MeanComplex[T Complex](array ...T) T {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	return Sum(array...) / T(complex(float64(len(array)), 0))
+}
+
+/* @example Conjugate
+gfn.Conjugate([]complex128{1 + 2i, 3 - 4i})  // []complex128{1 - 2i, 3 + 4i}
+*/
+
+// Conjugate returns a new array with the complex conjugate of every value.
+func Conjugate[T Complex](array []T) []T {
+	return Map(array, func(v T) T {
+		return T(cmplx.Conj(complex128(v)))
+	})
+}
+
+/* @example Phase
+gfn.Phase([]complex128{1, 1i, -1})  // []float64{0, math.Pi / 2, math.Pi}
+*/
+
+// Phase returns a new array with the phase (argument) of every value, in the
+// range [-Pi, Pi].
+func Phase[T Complex](array []T) []float64 {
+	return Map(array, func(v T) float64 {
+		return cmplx.Phase(complex128(v))
+	})
+}
+
+/* @example SumOf
+gfn.SumOf(gfn.NewDecimal(150, 2), gfn.NewDecimal(25, 1))  // 4.00
+*/
+
+// SumOf returns the sum of all values in the array, for types implementing
+// Adder. For the pointer types of math/big, please use SumBig and MeanBig.
+func SumOf[T Adder[T]](array ...T) T {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := array[0]
+	for _, v := range array[1:] {
+		res = res.Add(v)
+	}
+	return res
+}
+
+/* @example SumBig
+gfn.SumBig(big.NewInt(1), big.NewInt(2), big.NewInt(3))  // 6
+*/
+
+// SumBig returns the sum of all values in the array as a new value, for the
+// pointer types of math/big. The values in the array are not modified. Like
+// SumOf, it panics if the array is empty.
+func SumBig[E any, T BigAdder[E, T]](array ...T) T {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := T(new(E))
+	for _, v := range array {
+		res.Add(res, v)
+	}
+	return res
+}
+
+/* @example MaxOf
+gfn.MaxOf(big.NewInt(1), big.NewInt(3), big.NewInt(2))       // 3
+gfn.MaxOf(gfn.NewDecimal(150, 2), gfn.NewDecimal(25, 1))     // 2.5
+*/
+
+// MaxOf returns the maximum value in the array, for types implementing
+// Comparer. If several values share the maximum, the first one is returned.
+func MaxOf[T Comparer[T]](array ...T) T {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := array[0]
+	for _, v := range array[1:] {
+		if v.Cmp(res) > 0 {
+			res = v
+		}
+	}
+	return res
+}
+
+/* @example MinOf
+gfn.MinOf(big.NewInt(1), big.NewInt(3), big.NewInt(2))       // 1
+gfn.MinOf(gfn.NewDecimal(150, 2), gfn.NewDecimal(25, 1))     // 1.50
+*/
+
+// MinOf returns the minimum value in the array, for types implementing
+// Comparer. If several values share the minimum, the first one is returned.
+func MinOf[T Comparer[T]](array ...T) T {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := array[0]
+	for _, v := range array[1:] {
+		if v.Cmp(res) < 0 {
+			res = v
+		}
+	}
+	return res
+}
+
+/* @example MeanOf
+gfn.MeanOf(gfn.NewDecimal(100, 2), gfn.NewDecimal(200, 2), gfn.NewDecimal(200, 2))  // 1.67
+*/
+
+// MeanOf returns the mean of all values in the array, for types implementing
+// Averager. The sum is divided by the length of the array with DivInt, so the
+// precision and rounding of the result are decided by the type.
+func MeanOf[T Averager[T]](array ...T) T {
+	return SumOf(array...).DivInt(len(array))
+}
+
+/* @example MeanBig
+gfn.MeanBig(big.NewInt(1), big.NewInt(2))            // 3/2
+gfn.MeanBig(big.NewFloat(0.5), big.NewFloat(1))      // 3/4
+gfn.MeanBig(big.NewRat(1, 3), big.NewRat(2, 3))      // 1/2
+*/
+
+// MeanBig returns the exact mean of all values in the array as a new
+// *big.Rat, for the pointer types of math/big, which have no DivInt to work
+// with MeanOf. Use Rat.FloatString or Float.SetRat to convert the result.
+// The values in the array are not modified. It panics if the array is empty
+// or contains an infinite *big.Float.
+func MeanBig[T *big.Int | *big.Float | *big.Rat](array ...T) *big.Rat {
+	if len(array) == 0 {
+		panic("array is empty")
+	}
+
+	res := new(big.Rat)
+	r := new(big.Rat)
+	for _, v := range array {
+		switch v := any(v).(type) {
+		case *big.Int:
+			r.SetInt(v)
+		case *big.Float:
+			if v.IsInf() {
+				panic("infinite value")
+			}
+			v.Rat(r)
+		case *big.Rat:
+			r.Set(v)
+		}
+		res.Add(res, r)
+	}
+	return res.Quo(res, new(big.Rat).SetInt64(int64(len(array))))
+}
+
+// toleranceMode is the way a Tolerance compares two floats.
+type toleranceMode int
+
+const (
+	absoluteTolerance toleranceMode = iota
+	relativeTolerance
+	ulpTolerance
+)
+
+// Tolerance decides when two floats are approximately equal, it is created
+// by AbsTolerance, RelTolerance or ULPTolerance. Equal values, including
+// infinities of the same sign, are always approximately equal, while an
+// infinity is never approximately equal to a finite value. NaN is not equal
+// to anything unless WithNaNEqual is used.
+type Tolerance struct {
+	mode     toleranceMode
+	epsilon  float64
+	ulps     uint64
+	nanEqual bool
+}
+
+/* @example AbsTolerance
+gfn.AlmostEqual(1.0, 1.0001, gfn.AbsTolerance(1e-3))  // true
+gfn.AlmostEqual(1000.0, 1000.1, gfn.AbsTolerance(1e-3))  // false
+*/
+
+// AbsTolerance returns a Tolerance under which a and b are approximately
+// equal if |a-b| <= epsilon. It panics if epsilon is negative or NaN.
+func AbsTolerance(epsilon float64) Tolerance {
+	checkEpsilon(epsilon)
+	return Tolerance{mode: absoluteTolerance, epsilon: epsilon}
+}
+
+/* @example RelTolerance
+gfn.AlmostEqual(1000.0, 1000.1, gfn.RelTolerance(1e-3))  // true
+gfn.AlmostEqual(0.001, 0.002, gfn.RelTolerance(1e-3))    // false
+*/
+
+// RelTolerance returns a Tolerance under which a and b are approximately
+// equal if |a-b| <= epsilon * max(|a|, |b|). For epsilon less than 1, no
+// value but 0 is approximately equal to 0 under it, use AbsTolerance to
+// compare values near 0. It panics if epsilon is negative or NaN.
+func RelTolerance(epsilon float64) Tolerance {
+	checkEpsilon(epsilon)
+	return Tolerance{mode: relativeTolerance, epsilon: epsilon}
+}
+
+/* @example ULPTolerance
+a, b := 0.1, 0.2
+gfn.AlmostEqual(a+b, 0.3, gfn.ULPTolerance(1))  // true
+gfn.AlmostEqual(a+b, 0.3, gfn.ULPTolerance(0))  // false
+*/
+
+// ULPTolerance returns a Tolerance under which a and b are approximately
+// equal if there are at most ulps representable floats between them, counted
+// in the precision of the compared type. 0 and -0 are equal.
+func ULPTolerance(ulps uint64) Tolerance {
+	return Tolerance{mode: ulpTolerance, ulps: ulps}
+}
+
+/* @example Tolerance.WithNaNEqual
+gfn.AlmostEqual(math.NaN(), math.NaN(), gfn.AbsTolerance(1e-9))                 // false
+gfn.AlmostEqual(math.NaN(), math.NaN(), gfn.AbsTolerance(1e-9).WithNaNEqual())  // true
+*/
+
+// WithNaNEqual returns a copy of the Tolerance under which NaN is
+// approximately equal to NaN. NaN is still not equal to any other value.
+func (t Tolerance) WithNaNEqual() Tolerance {
+	t.nanEqual = true
+	return t
+}
+
+func checkEpsilon(epsilon float64) {
+	if epsilon < 0 || math.IsNaN(epsilon) {
+		panic("epsilon must be a non-negative number")
+	}
+}
+
+/* @example AlmostEqual
+a, b := 0.1, 0.2
+a+b == 0.3                                         // false
+gfn.AlmostEqual(a+b, 0.3, gfn.AbsTolerance(1e-9))  // true
+gfn.AlmostEqual(a+b, 0.3, gfn.ULPTolerance(1))     // true
+gfn.AlmostEqual(1.0, 1.1, gfn.RelTolerance(0.01))  // false
+*/
+
+// AlmostEqual returns true if a and b are approximately equal under the given Tolerance.
+func AlmostEqual[T Float](a, b T, tol Tolerance) bool {
+	if isNaN(a) || isNaN(b) {
+		return tol.nanEqual && isNaN(a) && isNaN(b)
+	}
+	if a == b {
+		return true
+	}
+	if math.IsInf(float64(a), 0) || math.IsInf(float64(b), 0) {
+		return false
+	}
+
+	switch tol.mode {
+	case absoluteTolerance:
+		return math.Abs(float64(a)-float64(b)) <= tol.epsilon
+	case relativeTolerance:
+		return math.Abs(float64(a)-float64(b)) <= tol.epsilon*math.Max(math.Abs(float64(a)), math.Abs(float64(b)))
+	default:
+		return ulpDistance(a, b) <= tol.ulps
+	}
+}
+
+// ulpDistance returns the number of representable values of T between a and b.
+func ulpDistance[T Float](a, b T) uint64 {
+	var x, y int64
+	if isFloat32[T]() {
+		x, y = int64(orderedBits32(float32(a))), int64(orderedBits32(float32(b)))
+	} else {
+		x, y = orderedBits64(float64(a)), orderedBits64(float64(b))
+	}
+	if x < y {
+		x, y = y, x
+	}
+	return uint64(x) - uint64(y)
+}
+
+// isFloat32 reports whether T has the precision of float32.
+func isFloat32[T Float]() bool {
+	smallest := math.SmallestNonzeroFloat64
+	return T(smallest) == 0
+}
+
+// orderedBits64 maps the bits of a float64 to an int64 with the same order
+// as the floats, where 0 and -0 are both 0.
+func orderedBits64(f float64) int64 {
+	bits := int64(math.Float64bits(f))
+	if bits < 0 {
+		return math.MinInt64 - bits
+	}
+	return bits
+}
+
+func orderedBits32(f float32) int32 {
+	bits := int32(math.Float32bits(f))
+	if bits < 0 {
+		return math.MinInt32 - bits
+	}
+	return bits
+}
@@ -3,6 +3,7 @@ package gfn_test
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -220,6 +221,281 @@ func TestDivMod(t *testing.T) {
 	})
 }
 
+func TestAddChecked(t *testing.T) {
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			res, ok := AddChecked(int8(a), int8(b))
+			expected := a + b
+			AssertEqual(t, expected >= math.MinInt8 && expected <= math.MaxInt8, ok, fmt.Sprintf("%d+%d", a, b))
+			AssertEqual(t, int8(expected), res, fmt.Sprintf("%d+%d", a, b))
+		}
+	}
+	for a := 0; a <= math.MaxUint8; a++ {
+		for b := 0; b <= math.MaxUint8; b++ {
+			res, ok := AddChecked(uint8(a), uint8(b))
+			AssertEqual(t, a+b <= math.MaxUint8, ok, fmt.Sprintf("%d+%d", a, b))
+			AssertEqual(t, uint8(a+b), res, fmt.Sprintf("%d+%d", a, b))
+		}
+	}
+
+	res, ok := AddChecked(math.MaxInt64, 1)
+	AssertFalse(t, ok)
+	AssertEqual(t, math.MinInt64, res)
+	res, ok = AddChecked(math.MaxInt64, -1)
+	AssertTrue(t, ok)
+	AssertEqual(t, math.MaxInt64-1, res)
+	_, ok = AddChecked(uint64(math.MaxUint64), 1)
+	AssertFalse(t, ok)
+	_, ok = AddChecked(uintptr(1), 1)
+	AssertTrue(t, ok)
+}
+
+func TestSubChecked(t *testing.T) {
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			res, ok := SubChecked(int8(a), int8(b))
+			expected := a - b
+			AssertEqual(t, expected >= math.MinInt8 && expected <= math.MaxInt8, ok, fmt.Sprintf("%d-%d", a, b))
+			AssertEqual(t, int8(expected), res, fmt.Sprintf("%d-%d", a, b))
+		}
+	}
+	for a := 0; a <= math.MaxUint8; a++ {
+		for b := 0; b <= math.MaxUint8; b++ {
+			res, ok := SubChecked(uint8(a), uint8(b))
+			AssertEqual(t, a >= b, ok, fmt.Sprintf("%d-%d", a, b))
+			AssertEqual(t, uint8(a-b), res, fmt.Sprintf("%d-%d", a, b))
+		}
+	}
+
+	_, ok := SubChecked(math.MinInt64, 1)
+	AssertFalse(t, ok)
+	res, ok := SubChecked[uint](0, 1)
+	AssertFalse(t, ok)
+	AssertEqual(t, uint(math.MaxUint), res)
+}
+
+func TestMulChecked(t *testing.T) {
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			res, ok := MulChecked(int8(a), int8(b))
+			expected := a * b
+			AssertEqual(t, expected >= math.MinInt8 && expected <= math.MaxInt8, ok, fmt.Sprintf("%d*%d", a, b))
+			AssertEqual(t, int8(expected), res, fmt.Sprintf("%d*%d", a, b))
+		}
+	}
+	for a := 0; a <= math.MaxUint8; a++ {
+		for b := 0; b <= math.MaxUint8; b++ {
+			res, ok := MulChecked(uint8(a), uint8(b))
+			AssertEqual(t, a*b <= math.MaxUint8, ok, fmt.Sprintf("%d*%d", a, b))
+			AssertEqual(t, uint8(a*b), res, fmt.Sprintf("%d*%d", a, b))
+		}
+	}
+
+	_, ok := MulChecked(math.MinInt64, -1)
+	AssertFalse(t, ok)
+	_, ok = MulChecked(-1, math.MinInt64)
+	AssertFalse(t, ok)
+	_, ok = MulChecked(math.MaxInt64, -1)
+	AssertTrue(t, ok)
+	_, ok = MulChecked(int64(1<<32), 1<<31)
+	AssertFalse(t, ok)
+	_, ok = MulChecked(int64(1<<31), 1<<31)
+	AssertTrue(t, ok)
+}
+
+func TestAbsChecked(t *testing.T) {
+	for x := math.MinInt8 + 1; x <= math.MaxInt8; x++ {
+		res, ok := AbsChecked(int8(x))
+		AssertTrue(t, ok)
+		AssertEqual(t, Abs(int8(x)), res)
+	}
+	res, ok := AbsChecked[int8](math.MinInt8)
+	AssertFalse(t, ok)
+	AssertEqual(t, int8(math.MinInt8), res)
+	_, ok = AbsChecked(math.MinInt64)
+	AssertFalse(t, ok)
+	uRes, ok := AbsChecked(uint(math.MaxUint))
+	AssertTrue(t, ok)
+	AssertEqual(t, uint(math.MaxUint), uRes)
+}
+
+func TestDivModChecked(t *testing.T) {
+	div, mod, ok := DivModChecked(10, 3)
+	AssertTrue(t, ok)
+	AssertEqual(t, 3, div)
+	AssertEqual(t, 1, mod)
+
+	div, mod, ok = DivModChecked(-10, 3)
+	AssertTrue(t, ok)
+	AssertEqual(t, -3, div)
+	AssertEqual(t, -1, mod)
+
+	_, _, ok = DivModChecked(10, 0)
+	AssertFalse(t, ok)
+	_, _, ok = DivModChecked[uint](10, 0)
+	AssertFalse(t, ok)
+	_, _, ok = DivModChecked[int8](math.MinInt8, -1)
+	AssertFalse(t, ok)
+	uDiv, _, ok := DivModChecked[uint8](math.MaxUint8, math.MaxUint8)
+	AssertTrue(t, ok)
+	AssertEqual(t, uint8(1), uDiv)
+}
+
+func TestSumChecked(t *testing.T) {
+	res, ok := SumChecked(1, 2, 3)
+	AssertTrue(t, ok)
+	AssertEqual(t, 6, res)
+
+	int8Res, ok := SumChecked([]int8{100, 100, -100}...)
+	AssertFalse(t, ok)
+	AssertEqual(t, int8(100), int8Res)
+
+	int8Res, ok = SumChecked([]int8{100, -100, 100}...)
+	AssertTrue(t, ok)
+	AssertEqual(t, int8(100), int8Res)
+
+	_, ok = SumChecked([]uint64{math.MaxUint64, 1}...)
+	AssertFalse(t, ok)
+
+	AssertPanics(t, func() {
+		SumChecked[int]()
+	})
+}
+
+func TestProductChecked(t *testing.T) {
+	res, ok := ProductChecked(1, 2, 3, 4)
+	AssertTrue(t, ok)
+	AssertEqual(t, 24, res)
+
+	int8Res, ok := ProductChecked([]int8{16, 16}...)
+	AssertFalse(t, ok)
+	AssertEqual(t, int8(0), int8Res)
+
+	_, ok = ProductChecked([]int8{-2, -64}...)
+	AssertFalse(t, ok)
+	_, ok = ProductChecked([]int8{2, -64}...)
+	AssertTrue(t, ok)
+	_, ok = ProductChecked([]uint16{256, 256, 0}...)
+	AssertFalse(t, ok)
+
+	AssertPanics(t, func() {
+		ProductChecked[int]()
+	})
+}
+
+func TestSaturatingAdd(t *testing.T) {
+	AssertEqual(t, int8(127), SaturatingAdd[int8](100, 100))
+	AssertEqual(t, int8(-128), SaturatingAdd[int8](-100, -100))
+	AssertEqual(t, int8(0), SaturatingAdd[int8](-100, 100))
+	AssertEqual(t, uint8(255), SaturatingAdd[uint8](200, 100))
+	AssertEqual(t, uint8(250), SaturatingAdd[uint8](200, 50))
+	AssertEqual(t, math.MaxInt64, SaturatingAdd(math.MaxInt64, 1))
+	AssertEqual(t, int32(math.MinInt32), SaturatingAdd[int32](math.MinInt32, -1))
+	AssertEqual(t, uint64(math.MaxUint64), SaturatingAdd[uint64](math.MaxUint64, math.MaxUint64))
+
+	// bounds of every signed type
+	AssertEqual(t, int16(math.MaxInt16), SaturatingAdd[int16](math.MaxInt16, 1))
+	AssertEqual(t, int16(math.MinInt16), SaturatingAdd[int16](math.MinInt16, -1))
+	AssertEqual(t, int32(math.MaxInt32), SaturatingAdd[int32](math.MaxInt32, 1))
+	AssertEqual(t, int64(math.MinInt64), SaturatingAdd[int64](math.MinInt64, -1))
+	AssertEqual(t, math.MinInt, SaturatingAdd(math.MinInt, -1))
+	type myInt int8
+	AssertEqual(t, myInt(127), SaturatingAdd[myInt](127, 1))
+	AssertEqual(t, myInt(-128), SaturatingAdd[myInt](-128, -1))
+}
+
+func TestSaturatingSub(t *testing.T) {
+	AssertEqual(t, int8(-128), SaturatingSub[int8](-100, 100))
+	AssertEqual(t, int8(127), SaturatingSub[int8](100, -100))
+	AssertEqual(t, int8(0), SaturatingSub[int8](100, 100))
+	AssertEqual(t, uint8(0), SaturatingSub[uint8](1, 2))
+	AssertEqual(t, uint8(1), SaturatingSub[uint8](2, 1))
+	AssertEqual(t, math.MinInt64, SaturatingSub(math.MinInt64, 1))
+}
+
+func TestSaturatingMul(t *testing.T) {
+	AssertEqual(t, int8(127), SaturatingMul[int8](100, 2))
+	AssertEqual(t, int8(-128), SaturatingMul[int8](-100, 2))
+	AssertEqual(t, int8(-128), SaturatingMul[int8](2, -100))
+	AssertEqual(t, int8(127), SaturatingMul[int8](-100, -2))
+	AssertEqual(t, int8(127), SaturatingMul[int8](-128, -1))
+	AssertEqual(t, int8(-100), SaturatingMul[int8](-100, 1))
+	AssertEqual(t, uint8(255), SaturatingMul[uint8](16, 16))
+	AssertEqual(t, int16(math.MaxInt16), SaturatingMul[int16](math.MaxInt16, math.MaxInt16))
+}
+
+func TestCumSum(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 3, 6, 10}, CumSum(1, 2, 3, 4))
+	AssertSliceEqual(t, []uint8{1, 3, 6}, CumSum[uint8](1, 2, 3))
+	AssertFloatSliceEqual(t, []float64{0.5, 1.5, 1}, CumSum(0.5, 1, -0.5))
+	AssertSliceEqual(t, []complex128{1 + 1i, 3 + 0i}, CumSum(1+1i, 2-1i))
+	AssertSliceEqual(t, []int{}, CumSum[int]())
+
+	array := []int{1, 2, 3}
+	CumSum(array...)
+	AssertSliceEqual(t, []int{1, 2, 3}, array)
+}
+
+func TestCumProd(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 2, 6, 24}, CumProd(1, 2, 3, 4))
+	AssertSliceEqual(t, []int{-2, 0, 0}, CumProd(-2, 0, 5))
+	AssertFloatSliceEqual(t, []float64{0.5, 0.25}, CumProd(0.5, 0.5))
+	AssertSliceEqual(t, []int{}, CumProd[int]())
+}
+
+func TestCumMax(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 3, 3, 5, 5}, CumMax(1, 3, 2, 5, 4))
+	AssertSliceEqual(t, []string{"b", "b", "c"}, CumMax("b", "a", "c"))
+	AssertSliceEqual(t, []int{}, CumMax[int]())
+
+	res := CumMax(math.NaN(), 1.0, math.NaN(), 0.5, 2)
+	AssertTrue(t, math.IsNaN(res[0]))
+	AssertSliceEqual(t, []float64{1, 1, 1, 2}, res[1:])
+}
+
+func TestCumMin(t *testing.T) {
+	AssertSliceEqual(t, []int{5, 3, 3, 1, 1}, CumMin(5, 3, 4, 1, 2))
+	AssertSliceEqual(t, []string{"b", "a", "a"}, CumMin("b", "a", "c"))
+	AssertSliceEqual(t, []int{}, CumMin[int]())
+
+	res := CumMin(math.NaN(), math.NaN(), 1.0, math.NaN(), 0.5, 2)
+	AssertTrue(t, math.IsNaN(res[0]))
+	AssertTrue(t, math.IsNaN(res[1]))
+	AssertSliceEqual(t, []float64{1, 1, 0.5, 0.5}, res[2:])
+}
+
+func TestDiffOrder(t *testing.T) {
+	AssertSliceEqual(t, []int{2, 3, 4}, Diff([]int{1, 3, 6, 10}, 1))
+	AssertSliceEqual(t, []int{1, 1}, Diff([]int{1, 3, 6, 10}, 2))
+	AssertSliceEqual(t, []int{0}, Diff([]int{1, 3, 6, 10}, 3))
+	AssertSliceEqual(t, []int{}, Diff([]int{1, 3, 6, 10}, 4))
+	AssertSliceEqual(t, []int{}, Diff([]int{1, 3, 6, 10}, 100))
+	AssertSliceEqual(t, []int{1, 3, 6, 10}, Diff([]int{1, 3, 6, 10}, 0))
+	AssertSliceEqual(t, []int{-5, 5}, Diff([]int{5, 0, 5}, 1))
+	AssertFloatSliceEqual(t, []float64{0.5, -1}, Diff([]float64{1, 1.5, 0.5}, 1))
+	AssertSliceEqual(t, []int{}, Diff([]int{}, 0))
+
+	// inverse of CumSum
+	array := []int{3, 1, 4, 1, 5}
+	AssertSliceEqual(t, array[1:], Diff(CumSum(array...), 1))
+
+	AssertPanics(t, func() {
+		Diff([]int{1, 2}, -1)
+	})
+}
+
+func TestPercentChange(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{0.1, -0.1}, PercentChange(100, 110, 99))
+	AssertFloatSliceEqual(t, []float64{-0.5, 1}, PercentChange[uint](4, 2, 4))
+	AssertFloatSliceEqual(t, []float64{}, PercentChange(1.0))
+	AssertFloatSliceEqual(t, []float64{}, PercentChange[int]())
+
+	res := PercentChange(0, 1, 0, 0)
+	AssertTrue(t, math.IsInf(res[0], 1))
+	AssertEqual(t, -1.0, res[1])
+	AssertTrue(t, math.IsNaN(res[2]))
+}
+
 func TestSumBy(t *testing.T) {
 	type Product struct {
 		name   string
@@ -423,3 +699,564 @@ func TestModeBy(t *testing.T) {
 		})
 	})
 }
+
+func TestMultiMode(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 5}, MultiMode([]int{1, 1, 5, 5, 2}))
+	AssertSliceEqual(t, []int{5, 1}, MultiMode([]int{2, 5, 1, 1, 5}))
+	AssertSliceEqual(t, []string{"a", "b", "c"}, MultiMode([]string{"a", "b", "c"}))
+	AssertSliceEqual(t, []int{5}, MultiMode([]int{1, 1, 1, 5, 5, 5, 5, 2, 2, 2}))
+
+	AssertPanics(t, func() {
+		MultiMode([]int{})
+	})
+}
+
+func TestArgMultiMode(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 3}, ArgMultiMode([]int{2, 1, 1, 5, 5}))
+	AssertSliceEqual(t, []int{0}, ArgMultiMode([]string{"a", "b", "a"}))
+	AssertSliceEqual(t, []int{0, 1}, ArgMultiMode([]bool{true, false}))
+
+	AssertPanics(t, func() {
+		ArgMultiMode([]int{})
+	})
+}
+
+func TestMultiModeBy(t *testing.T) {
+	type Product struct {
+		name   string
+		amount int
+	}
+	products := []Product{
+		{"apple", 10},
+		{"banana", 20},
+		{"orange", 10},
+		{"grape", 20},
+		{"lemon", 30},
+	}
+	AssertSliceEqual(t, []Product{{"apple", 10}, {"banana", 20}}, MultiModeBy(products, func(p Product) int {
+		return p.amount
+	}))
+	AssertSliceEqual(t, products, MultiModeBy(products, func(p Product) string {
+		return p.name
+	}))
+
+	AssertPanics(t, func() {
+		MultiModeBy([]int{}, func(i int) int {
+			return i
+		})
+	})
+}
+
+func TestMaxAllBy(t *testing.T) {
+	type Product struct {
+		name   string
+		amount int
+	}
+	products := []Product{
+		{"apple", 10},
+		{"banana", 30},
+		{"orange", 30},
+		{"lemon", 20},
+	}
+	AssertSliceEqual(t, []Product{{"banana", 30}, {"orange", 30}}, MaxAllBy(products, func(p Product) int {
+		return p.amount
+	}))
+	AssertSliceEqual(t, []Product{{"orange", 30}}, MaxAllBy(products, func(p Product) string {
+		return p.name
+	}))
+	AssertSliceEqual(t, []int{3, 3}, MaxAllBy([]int{1, 3, 2, 3}, func(i int) int {
+		return i
+	}))
+
+	AssertPanics(t, func() {
+		MaxAllBy([]int{}, func(i int) int {
+			return i
+		})
+	})
+}
+
+func TestArgMaxAllBy(t *testing.T) {
+	AssertSliceEqual(t, []int{0, 2}, ArgMaxAllBy([]string{"ab", "c", "de"}, func(s string) int {
+		return len(s)
+	}))
+	AssertSliceEqual(t, []int{3}, ArgMaxAllBy([]int{3, 3, 1, 4}, func(i int) int {
+		return i
+	}))
+	AssertSliceEqual(t, []int{0}, ArgMaxAllBy([]int{1}, func(i int) int {
+		return i
+	}))
+
+	AssertPanics(t, func() {
+		ArgMaxAllBy([]int{}, func(i int) int {
+			return i
+		})
+	})
+}
+
+func TestMinAllBy(t *testing.T) {
+	type Product struct {
+		name   string
+		amount int
+	}
+	products := []Product{
+		{"apple", 10},
+		{"banana", 30},
+		{"orange", 10},
+	}
+	AssertSliceEqual(t, []Product{{"apple", 10}, {"orange", 10}}, MinAllBy(products, func(p Product) int {
+		return p.amount
+	}))
+	AssertSliceEqual(t, []float64{0.5}, MinAllBy([]float64{2, 0.5, 1}, func(f float64) float64 {
+		return f
+	}))
+
+	AssertPanics(t, func() {
+		MinAllBy([]int{}, func(i int) int {
+			return i
+		})
+	})
+}
+
+func TestArgMinAllBy(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 2}, ArgMinAllBy([]string{"ab", "c", "d"}, func(s string) int {
+		return len(s)
+	}))
+	AssertSliceEqual(t, []int{2}, ArgMinAllBy([]int{3, 3, 1, 4}, func(i int) int {
+		return i
+	}))
+
+	AssertPanics(t, func() {
+		ArgMinAllBy([]int{}, func(i int) int {
+			return i
+		})
+	})
+}
+
+func TestArgMax(t *testing.T) {
+	AssertEqual(t, 2, ArgMax(1, 5, 9, 9, 2))
+	AssertEqual(t, 0, ArgMax(7))
+	AssertEqual(t, 1, ArgMax("ab", "cd", "a"))
+	AssertEqual(t, 1, ArgMax(math.NaN(), 1.5, 0.5))
+	AssertEqual(t, 2, ArgMax(1.5, math.NaN(), math.Inf(1)))
+	AssertEqual(t, 0, ArgMax(math.NaN(), math.NaN()))
+
+	AssertPanics(t, func() {
+		ArgMax[int]()
+	})
+}
+
+func TestArgMin(t *testing.T) {
+	AssertEqual(t, 1, ArgMin(3, 1, 9, 1))
+	AssertEqual(t, 0, ArgMin(uint8(0), uint8(0)))
+	AssertEqual(t, 2, ArgMin(math.NaN(), 1.5, 0.5))
+	AssertEqual(t, 1, ArgMin(1.5, math.Inf(-1), math.NaN()))
+	AssertEqual(t, 0, ArgMin(math.NaN()))
+
+	AssertPanics(t, func() {
+		ArgMin[float64]()
+	})
+}
+
+func TestArgMaxBy(t *testing.T) {
+	names := []string{"alice", "bob", "carol"}
+	scores := []int{80, 95, 95}
+	AssertEqual(t, "bob", names[ArgMaxBy(scores, func(s int) int {
+		return s
+	})])
+	AssertEqual(t, 0, ArgMaxBy(names, func(s string) int {
+		return len(s)
+	}))
+	AssertEqual(t, 2, ArgMaxBy(names, func(s string) string {
+		return s
+	}))
+
+	AssertPanics(t, func() {
+		ArgMaxBy([]int{}, func(i int) int {
+			return i
+		})
+	})
+}
+
+func TestArgMinBy(t *testing.T) {
+	AssertEqual(t, 1, ArgMinBy([]string{"abc", "d", "ef", "g"}, func(s string) int {
+		return len(s)
+	}))
+	AssertEqual(t, 0, ArgMinBy([]int{1}, func(i int) int {
+		return i
+	}))
+	AssertEqual(t, 3, ArgMinBy([]int{1, 2, 3, -4}, func(i int) float64 {
+		return float64(i)
+	}))
+
+	AssertPanics(t, func() {
+		ArgMinBy([]int{}, func(i int) int {
+			return i
+		})
+	})
+}
+
+func TestDot(t *testing.T) {
+	AssertFloatEqual(t, 32, Dot([]float64{1, 2, 3}, []float64{4, 5, 6}))
+	AssertFloatEqual(t, 22, Dot([]float64{1, math.NaN(), 3}, []float64{4, 5, 6}))
+	AssertEqual(t, float32(-1), Dot([]float32{0.5, 1}, []float32{-2, 0}))
+	AssertFloatEqual(t, 0, Dot([]float64{}, []float64{}))
+
+	AssertPanics(t, func() {
+		Dot([]float64{1}, []float64{1, 2})
+	})
+}
+
+func TestNorm(t *testing.T) {
+	AssertFloatEqual(t, 7, Norm([]float64{3, -4}, L1Norm))
+	AssertFloatEqual(t, 5, Norm([]float64{3, -4}, L2Norm))
+	AssertFloatEqual(t, 4, Norm([]float64{3, -4}, InfNorm))
+	AssertFloatEqual(t, 5, Norm([]float64{3, math.NaN(), -4}, L2Norm))
+	AssertFloatEqual(t, 0, Norm([]float64{}, L2Norm))
+	AssertFloatEqual(t, 0, Norm([]float64{math.NaN()}, InfNorm))
+	AssertTrue(t, math.IsInf(Norm([]float64{1, math.Inf(-1)}, InfNorm), 1))
+
+	AssertPanics(t, func() {
+		Norm([]float64{1}, NormOrder(10))
+	})
+	AssertPanics(t, func() {
+		Norm([]float64{}, NormOrder(9))
+	})
+	AssertPanics(t, func() {
+		Norm([]float64{math.NaN()}, NormOrder(-1))
+	})
+}
+
+func TestAdd(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{5, 7, 9}, Add([]float64{1, 2, 3}, []float64{4, 5, 6}))
+	AssertFloatSliceEqual(t, []float64{}, Add([]float64{}, []float64{}))
+
+	AssertPanics(t, func() {
+		Add([]float64{1, 2}, []float64{1})
+	})
+}
+
+func TestSub(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{-3, -3, -3}, Sub([]float64{1, 2, 3}, []float64{4, 5, 6}))
+
+	AssertPanics(t, func() {
+		Sub([]float64{1, 2}, []float64{})
+	})
+}
+
+func TestMul(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{4, 10, 18}, Mul([]float64{1, 2, 3}, []float64{4, 5, 6}))
+
+	AssertPanics(t, func() {
+		Mul([]float64{}, []float64{1})
+	})
+}
+
+func TestDiv(t *testing.T) {
+	res := Div([]float64{1, 2, 3, 0}, []float64{4, 5, 0, 0})
+	AssertFloatSliceEqual(t, []float64{0.25, 0.4}, res[:2])
+	AssertTrue(t, math.IsInf(res[2], 1))
+	AssertTrue(t, math.IsNaN(res[3]))
+
+	AssertPanics(t, func() {
+		Div([]float64{1}, []float64{1, 2})
+	})
+}
+
+func TestScale(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{2, 4, 6}, Scale([]float64{1, 2, 3}, 2))
+	AssertFloatSliceEqual(t, []float64{}, Scale([]float64{}, 2))
+	AssertSliceEqual(t, []float32{-0.5}, Scale([]float32{1}, -0.5))
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	AssertFloatEqual(t, math.Sqrt2/2, CosineSimilarity([]float64{1, 0}, []float64{1, 1}))
+	AssertFloatEqual(t, -1, CosineSimilarity([]float64{1, 2}, []float64{-2, -4}))
+	AssertFloatEqual(t, 0, CosineSimilarity([]float64{1, 0}, []float64{0, 1}))
+	AssertFloatEqual(t, 1, CosineSimilarity([]float64{1, math.NaN(), 2}, []float64{2, 1, 4}))
+	AssertFloatEqual(t, 0, CosineSimilarity([]float64{0, 0}, []float64{1, 1}))
+	AssertFloatEqual(t, 0, CosineSimilarity([]float64{}, []float64{}))
+
+	AssertPanics(t, func() {
+		CosineSimilarity([]float64{1}, []float64{1, 2})
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{0.6, -0.8}, Normalize([]float64{3, -4}, L2Norm))
+	AssertFloatSliceEqual(t, []float64{3.0 / 7, -4.0 / 7}, Normalize([]float64{3, -4}, L1Norm))
+	AssertFloatSliceEqual(t, []float64{0.75, -1}, Normalize([]float64{3, -4}, InfNorm))
+	AssertFloatSliceEqual(t, []float64{0, 0}, Normalize([]float64{0, 0}, L2Norm))
+
+	res := Normalize([]float64{3, math.NaN(), 4}, L2Norm)
+	AssertFloatEqual(t, 0.6, res[0])
+	AssertTrue(t, math.IsNaN(res[1]))
+	AssertFloatEqual(t, 0.8, res[2])
+}
+
+func TestStandardize(t *testing.T) {
+	res := Standardize([]float64{1, 2, 3, 4})
+	AssertFloatEqual(t, 0, Mean(res...))
+	AssertFloatEqual(t, 1, Norm(res, L2Norm)/2)
+	AssertTrue(t, res[0] < res[1] && res[1] < res[2] && res[2] < res[3])
+
+	AssertFloatSliceEqual(t, []float64{-1, 1}, Standardize([]float64{0, 10}))
+	AssertFloatSliceEqual(t, []float64{0, 0, 0}, Standardize([]float64{5, 5, 5}))
+	AssertFloatSliceEqual(t, []float64{}, Standardize([]float64{}))
+
+	res = Standardize([]float64{0, math.NaN(), 10})
+	AssertFloatEqual(t, -1, res[0])
+	AssertTrue(t, math.IsNaN(res[1]))
+	AssertFloatEqual(t, 1, res[2])
+}
+
+func TestMinMaxScale(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{0, 0.25, 0.5, 1}, MinMaxScale([]float64{1, 2, 3, 5}))
+	AssertFloatSliceEqual(t, []float64{0, 0}, MinMaxScale([]float64{2, 2}))
+	AssertFloatSliceEqual(t, []float64{}, MinMaxScale([]float64{}))
+
+	res := MinMaxScale([]float64{math.NaN(), 1, 3})
+	AssertTrue(t, math.IsNaN(res[0]))
+	AssertFloatSliceEqual(t, []float64{0, 1}, res[1:])
+}
+
+func TestAbsComplex(t *testing.T) {
+	AssertFloatEqual(t, 5, AbsComplex(3+4i))
+	AssertFloatEqual(t, 5, AbsComplex(complex64(-3-4i)))
+	AssertFloatEqual(t, 0, AbsComplex(0i))
+	AssertEqual(t, -3+0i, MaxBy([]complex128{1 + 1i, -3, 2i}, AbsComplex[complex128]))
+}
+
+func TestMeanComplex(t *testing.T) {
+	AssertEqual(t, 2+3i, MeanComplex(1+2i, 3+4i))
+	AssertEqual(t, complex64(1i), MeanComplex(complex64(1i)))
+	AssertEqual(t, 0i, MeanComplex(1+1i, -1-1i))
+
+	AssertPanics(t, func() {
+		MeanComplex[complex128]()
+	})
+}
+
+func TestConjugate(t *testing.T) {
+	AssertSliceEqual(t, []complex128{1 - 2i, 3 + 4i}, Conjugate([]complex128{1 + 2i, 3 - 4i}))
+	AssertSliceEqual(t, []complex64{5}, Conjugate([]complex64{5}))
+	AssertSliceEqual(t, []complex128{}, Conjugate([]complex128{}))
+}
+
+func TestPhase(t *testing.T) {
+	AssertFloatSliceEqual(t, []float64{0, math.Pi / 2, math.Pi, -math.Pi / 4}, Phase([]complex128{1, 1i, -1, 1 - 1i}))
+	AssertFloatSliceEqual(t, []float64{}, Phase([]complex64{}))
+}
+
+func TestSumOf(t *testing.T) {
+	AssertEqual(t, "4.00", SumOf(NewDecimal(150, 2), NewDecimal(25, 1)).String())
+	AssertEqual(t, "1", SumOf(NewDecimal(1, 0)).String())
+
+	AssertPanics(t, func() {
+		SumOf[Decimal]()
+	})
+}
+
+func TestSumBig(t *testing.T) {
+	a, b := big.NewInt(1), big.NewInt(2)
+	AssertEqual(t, "3", SumBig(a, b).String())
+	// values are not modified
+	AssertEqual(t, "1", a.String())
+
+	huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+	AssertEqual(t, "200000000000000000001", SumBig(huge, huge, big.NewInt(1)).String())
+	AssertEqual(t, "3/4", SumBig(big.NewRat(1, 2), big.NewRat(1, 4)).String())
+	AssertEqual(t, "2.5", SumBig(big.NewFloat(1), big.NewFloat(1.5)).String())
+
+	AssertPanics(t, func() {
+		SumBig[big.Int, *big.Int]()
+	})
+}
+
+func TestMaxOf(t *testing.T) {
+	AssertEqual(t, "3", MaxOf(big.NewInt(1), big.NewInt(3), big.NewInt(2)).String())
+	AssertEqual(t, "2.5", MaxOf(NewDecimal(150, 2), NewDecimal(25, 1)).String())
+	// the first maximum is returned
+	AssertEqual(t, "2.0", MaxOf(NewDecimal(20, 1), NewDecimal(2, 0)).String())
+
+	AssertPanics(t, func() {
+		MaxOf[*big.Int]()
+	})
+}
+
+func TestMinOf(t *testing.T) {
+	AssertEqual(t, "1", MinOf(big.NewInt(1), big.NewInt(3), big.NewInt(2)).String())
+	AssertEqual(t, "1.50", MinOf(NewDecimal(150, 2), NewDecimal(25, 1)).String())
+	AssertEqual(t, "-1/3", MinOf(big.NewRat(1, 2), big.NewRat(-1, 3)).String())
+
+	AssertPanics(t, func() {
+		MinOf[Decimal]()
+	})
+}
+
+func TestMeanOf(t *testing.T) {
+	AssertEqual(t, "1.67", MeanOf(NewDecimal(100, 2), NewDecimal(200, 2), NewDecimal(200, 2)).String())
+	AssertEqual(t, "2.5", MeanOf(NewDecimal(2, 0), NewDecimal(3, 0), NewDecimal(0, 1)).Add(NewDecimal(8, 1)).String())
+
+	AssertPanics(t, func() {
+		MeanOf[Decimal]()
+	})
+}
+
+func TestMeanBig(t *testing.T) {
+	a, b := big.NewInt(1), big.NewInt(2)
+	AssertEqual(t, "3/2", MeanBig(a, b).String())
+	// values are not modified
+	AssertEqual(t, "1", a.String())
+	AssertEqual(t, "2", b.String())
+
+	huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+	AssertEqual(t, "66666666666666666667", MeanBig(huge, huge, big.NewInt(1)).RatString())
+	AssertEqual(t, "200000000000000000000/3", MeanBig(huge, huge, big.NewInt(0)).String())
+	AssertEqual(t, "1/2", MeanBig(big.NewRat(1, 3), big.NewRat(2, 3)).String())
+	AssertEqual(t, "3/4", MeanBig(big.NewFloat(0.5), big.NewFloat(1)).String())
+	AssertEqual(t, "-1", MeanBig(big.NewInt(-1)).RatString())
+
+	AssertPanics(t, func() {
+		MeanBig[*big.Int]()
+	})
+	AssertPanics(t, func() {
+		MeanBig(big.NewFloat(1), new(big.Float).SetInf(false))
+	})
+}
+
+func TestMaxFunc(t *testing.T) {
+	type Version struct {
+		parts []int
+	}
+	less := func(a, b Version) bool {
+		for i := 0; i < len(a.parts) && i < len(b.parts); i++ {
+			if a.parts[i] != b.parts[i] {
+				return a.parts[i] < b.parts[i]
+			}
+		}
+		return len(a.parts) < len(b.parts)
+	}
+	versions := []Version{{[]int{1, 2}}, {[]int{1, 10}}, {[]int{1, 3, 1}}}
+	AssertSliceEqual(t, []int{1, 10}, MaxFunc(versions, less).parts)
+	AssertSliceEqual(t, []int{1, 2}, MinFunc(versions, less).parts)
+
+	// the first maximum is returned
+	pairs := [][]int{{1, 5}, {2, 5}, {3, 4}}
+	AssertSliceEqual(t, []int{1, 5}, MaxFunc(pairs, func(a, b []int) bool {
+		return a[1] < b[1]
+	}))
+
+	AssertPanics(t, func() {
+		MaxFunc([][]int{}, func(a, b []int) bool {
+			return len(a) < len(b)
+		})
+	})
+}
+
+func TestMinFunc(t *testing.T) {
+	sumLess := func(a, b []int) bool {
+		return Sum(a...) < Sum(b...)
+	}
+	AssertSliceEqual(t, []int{2}, MinFunc([][]int{{3, 1}, {2}, {2, 5}}, sumLess))
+	AssertSliceEqual(t, []int{2}, MinFunc([][]int{{2}, {1, 1}}, sumLess))
+
+	AssertPanics(t, func() {
+		MinFunc([][]int{}, sumLess)
+	})
+}
+
+func TestMinMaxFunc(t *testing.T) {
+	sumLess := func(a, b []int) bool {
+		return Sum(a...) < Sum(b...)
+	}
+	minimum, maximum := MinMaxFunc([][]int{{3, 1}, {2}, {2, 5}}, sumLess)
+	AssertSliceEqual(t, []int{2}, minimum)
+	AssertSliceEqual(t, []int{2, 5}, maximum)
+
+	minimum, maximum = MinMaxFunc([][]int{{1}}, sumLess)
+	AssertSliceEqual(t, []int{1}, minimum)
+	AssertSliceEqual(t, []int{1}, maximum)
+
+	AssertPanics(t, func() {
+		MinMaxFunc([][]int{}, sumLess)
+	})
+}
+
+func TestAbsTolerance(t *testing.T) {
+	AssertTrue(t, AlmostEqual(1.0, 1.0001, AbsTolerance(1e-3)))
+	AssertFalse(t, AlmostEqual(1000.0, 1000.1, AbsTolerance(1e-3)))
+	AssertTrue(t, AlmostEqual(1.0, 1.0, AbsTolerance(0)))
+
+	AssertPanics(t, func() {
+		AbsTolerance(-1)
+	})
+	AssertPanics(t, func() {
+		AbsTolerance(math.NaN())
+	})
+}
+
+func TestRelTolerance(t *testing.T) {
+	AssertTrue(t, AlmostEqual(1000.0, 1000.1, RelTolerance(1e-3)))
+	AssertFalse(t, AlmostEqual(0.001, 0.002, RelTolerance(1e-3)))
+	AssertFalse(t, AlmostEqual(0, 1e-300, RelTolerance(0.5)))
+	AssertTrue(t, AlmostEqual(0, -0.0, RelTolerance(0)))
+
+	AssertPanics(t, func() {
+		RelTolerance(-0.1)
+	})
+}
+
+func TestULPTolerance(t *testing.T) {
+	a, b := 0.1, 0.2
+	AssertTrue(t, AlmostEqual(a+b, 0.3, ULPTolerance(1)))
+	AssertFalse(t, AlmostEqual(a+b, 0.3, ULPTolerance(0)))
+
+	next := math.Nextafter(1, 2)
+	AssertTrue(t, AlmostEqual(1, math.Nextafter(next, 2), ULPTolerance(2)))
+	AssertFalse(t, AlmostEqual(1, math.Nextafter(next, 2), ULPTolerance(1)))
+
+	// crossing zero
+	tiny := math.SmallestNonzeroFloat64
+	AssertTrue(t, AlmostEqual(-tiny, tiny, ULPTolerance(2)))
+	AssertFalse(t, AlmostEqual(-tiny, tiny, ULPTolerance(1)))
+	AssertTrue(t, AlmostEqual(0, math.Copysign(0, -1), ULPTolerance(0)))
+
+	// float32 ulps are counted in float32
+	next32 := math.Nextafter32(1, 2)
+	AssertTrue(t, AlmostEqual(float32(1), next32, ULPTolerance(1)))
+	AssertFalse(t, AlmostEqual(float32(1), math.Nextafter32(next32, 2), ULPTolerance(1)))
+
+	AssertTrue(t, AlmostEqual(-math.MaxFloat64, math.MaxFloat64, ULPTolerance(math.MaxUint64)))
+}
+
+func TestToleranceWithNaNEqual(t *testing.T) {
+	nan := math.NaN()
+	for _, tol := range []Tolerance{AbsTolerance(1e-9), RelTolerance(1e-9), ULPTolerance(4)} {
+		AssertFalse(t, AlmostEqual(nan, nan, tol))
+		AssertTrue(t, AlmostEqual(nan, nan, tol.WithNaNEqual()))
+		AssertFalse(t, AlmostEqual(nan, 1, tol.WithNaNEqual()))
+		AssertFalse(t, AlmostEqual(1, nan, tol.WithNaNEqual()))
+	}
+
+	// the original tolerance is not modified
+	tol := AbsTolerance(1)
+	_ = tol.WithNaNEqual()
+	AssertFalse(t, AlmostEqual(nan, nan, tol))
+}
+
+func TestAlmostEqual(t *testing.T) {
+	inf := math.Inf(1)
+	for _, tol := range []Tolerance{AbsTolerance(math.MaxFloat64), RelTolerance(10), ULPTolerance(math.MaxUint64)} {
+		AssertTrue(t, AlmostEqual(inf, inf, tol))
+		AssertTrue(t, AlmostEqual(-inf, -inf, tol))
+		AssertFalse(t, AlmostEqual(inf, -inf, tol))
+		AssertFalse(t, AlmostEqual(inf, math.MaxFloat64, tol))
+		AssertFalse(t, AlmostEqual(1, -inf, tol))
+	}
+
+	a, b := 0.1, 0.2
+	AssertFalse(t, a+b == 0.3)
+	AssertTrue(t, AlmostEqual(a+b, 0.3, AbsTolerance(1e-9)))
+	AssertFalse(t, AlmostEqual(1.0, 1.1, RelTolerance(0.01)))
+	AssertTrue(t, AlmostEqual(float32(1.0), float32(1.05), RelTolerance(0.1)))
+}
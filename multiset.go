@@ -0,0 +1,224 @@
+package gfn
+
+import "sort"
+
+// multisetEntry is the count of a value and the order it was first inserted.
+type multisetEntry struct {
+	count int
+	seq   int
+}
+
+// Multiset is a collection of values with counts, similar to Python's
+// collections.Counter. Unlike the map returned by Counter, it remembers the
+// order in which values are first inserted, which is used by Elements, Keys
+// and to break ties in MostCommon. Only positive counts are kept.
+// The zero value is an empty multiset ready to use.
+// Multiset is not safe for concurrent use.
+type Multiset[T comparable] struct {
+	entries map[T]multisetEntry
+	next    int
+}
+
+/* @example NewMultiset
+m := gfn.NewMultiset("a", "b", "a", "c", "b", "a")
+m.Count("a")        // 3
+m.Count("d")        // 0
+m.Total()           // 6
+m.MostCommon(2)     // []gfn.Pair[string, int]{{"a", 3}, {"b", 2}}
+m.Elements()        // []string{"a", "a", "a", "b", "b", "c"}
+*/
+
+// NewMultiset returns a multiset counting the given values.
+func NewMultiset[T comparable](values ...T) *Multiset[T] {
+	m := &Multiset[T]{entries: make(map[T]multisetEntry)}
+	m.Insert(values...)
+	return m
+}
+
+/* @example MultisetFromCounter
+counter := gfn.Counter([]string{"b", "a", "b", "c", "a"})
+m := gfn.MultisetFromCounter(counter, func(a, b string) bool {
+	return a < b
+})
+m.MostCommon(-1)
+// []gfn.Pair[string, int]{{"a", 2}, {"b", 2}, {"c", 1}}
+*/
+
+// MultisetFromCounter converts the result of Counter or CounterBy to a
+// multiset. Since maps are not ordered, the values are inserted in the order
+// decided by less, which breaks ties in MostCommon deterministically. Values
+// with non-positive counts are skipped.
+func MultisetFromCounter[T comparable](counter map[T]int, less func(a, b T) bool) *Multiset[T] {
+	keys := Keys(counter)
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	m := NewMultiset[T]()
+	for _, k := range keys {
+		m.Set(k, counter[k])
+	}
+	return m
+}
+
+/* @example MultisetFromCounterBy
+type Employee struct {
+	name       string
+	department string
+}
+employees := []Employee{
+	{"Alice", "Accounting"},
+	{"Bob", "Sales"},
+	{"Dave", "Engineering"},
+	{"Eve", "Engineering"},
+}
+m := gfn.MultisetFromCounterBy(employees, func(e Employee) string {
+	return e.department
+})
+m.MostCommon(2)
+// []gfn.Pair[string, int]{{"Engineering", 2}, {"Accounting", 1}}
+*/
+
+// MultisetFromCounterBy counts the keys returned by fn like CounterBy, but
+// returns a multiset in which the keys are inserted in the order they first
+// appear in the array.
+func MultisetFromCounterBy[T any, U comparable](array []T, fn func(T) U) *Multiset[U] {
+	m := NewMultiset[U]()
+	for _, v := range array {
+		m.Insert(fn(v))
+	}
+	return m
+}
+
+// Insert increases the count of each given value by one.
+func (m *Multiset[T]) Insert(values ...T) {
+	for _, v := range values {
+		m.Set(v, m.Count(v)+1)
+	}
+}
+
+// Set sets the count of a value. A count less than or equal to zero removes the value.
+func (m *Multiset[T]) Set(value T, count int) {
+	if count <= 0 {
+		delete(m.entries, value)
+		return
+	}
+	if m.entries == nil {
+		m.entries = make(map[T]multisetEntry)
+	}
+	entry, ok := m.entries[value]
+	if !ok {
+		entry.seq = m.next
+		m.next++
+	}
+	entry.count = count
+	m.entries[value] = entry
+}
+
+// Count returns the count of a value, 0 if the value is not in the multiset.
+func (m *Multiset[T]) Count(value T) int {
+	return m.entries[value].count
+}
+
+// Len returns the number of distinct values in the multiset.
+func (m *Multiset[T]) Len() int {
+	return len(m.entries)
+}
+
+// Total returns the sum of all counts.
+func (m *Multiset[T]) Total() int {
+	total := 0
+	for _, entry := range m.entries {
+		total += entry.count
+	}
+	return total
+}
+
+// Keys returns the distinct values of the multiset in insertion order.
+func (m *Multiset[T]) Keys() []T {
+	keys := Keys(m.entries)
+	sort.Slice(keys, func(i, j int) bool {
+		return m.entries[keys[i]].seq < m.entries[keys[j]].seq
+	})
+	return keys
+}
+
+// Elements returns every value repeated as many times as its count, in insertion order.
+func (m *Multiset[T]) Elements() []T {
+	res := make([]T, 0, m.Total())
+	for _, k := range m.Keys() {
+		for i := 0; i < m.entries[k].count; i++ {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+// ToMap returns the counts as a map, which is the same format as the result of Counter.
+func (m *Multiset[T]) ToMap() map[T]int {
+	res := make(map[T]int, len(m.entries))
+	for k, entry := range m.entries {
+		res[k] = entry.count
+	}
+	return res
+}
+
+// MostCommon returns the n values with the highest counts and their counts,
+// from the most common to the least. Values with equal counts are ordered by
+// insertion order. Negative n or n larger than Len returns all values.
+func (m *Multiset[T]) MostCommon(n int) []Pair[T, int] {
+	keys := m.Keys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return m.entries[keys[i]].count > m.entries[keys[j]].count
+	})
+	if n >= 0 && n < len(keys) {
+		keys = keys[:n]
+	}
+	return Map(keys, func(k T) Pair[T, int] {
+		return Pair[T, int]{k, m.entries[k].count}
+	})
+}
+
+/* @example Multiset.Add
+a := gfn.NewMultiset("a", "a", "b")
+b := gfn.NewMultiset("a", "c")
+a.Add(b).ToMap()        // map[string]int{"a": 3, "b": 1, "c": 1}
+a.Subtract(b).ToMap()   // map[string]int{"a": 1, "b": 1}
+a.Union(b).ToMap()      // map[string]int{"a": 2, "b": 1, "c": 1}
+a.Intersect(b).ToMap()  // map[string]int{"a": 1}
+*/
+
+// Add returns a new multiset where each count is the sum of the counts in both multisets.
+func (m *Multiset[T]) Add(other *Multiset[T]) *Multiset[T] {
+	return m.combine(other, func(a, b int) int { return a + b })
+}
+
+// Subtract returns a new multiset where each count is the count in m minus
+// the count in other, only positive counts are kept.
+func (m *Multiset[T]) Subtract(other *Multiset[T]) *Multiset[T] {
+	return m.combine(other, func(a, b int) int { return a - b })
+}
+
+// Union returns a new multiset where each count is the maximum of the counts in both multisets.
+func (m *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	return m.combine(other, func(a, b int) int { return Max(a, b) })
+}
+
+// Intersect returns a new multiset where each count is the minimum of the counts in both multisets.
+func (m *Multiset[T]) Intersect(other *Multiset[T]) *Multiset[T] {
+	return m.combine(other, func(a, b int) int { return Min(a, b) })
+}
+
+// combine applies op to the counts of every value in either multiset. The
+// result keeps the insertion order of m, followed by new values of other.
+func (m *Multiset[T]) combine(other *Multiset[T], op func(a, b int) int) *Multiset[T] {
+	res := NewMultiset[T]()
+	for _, k := range m.Keys() {
+		res.Set(k, op(m.Count(k), other.Count(k)))
+	}
+	for _, k := range other.Keys() {
+		if _, ok := m.entries[k]; !ok {
+			res.Set(k, op(0, other.Count(k)))
+		}
+	}
+	return res
+}
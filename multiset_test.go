@@ -0,0 +1,118 @@
+package gfn_test
+
+import (
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func TestNewMultiset(t *testing.T) {
+	m := NewMultiset("a", "b", "a", "c", "b", "a")
+	AssertEqual(t, 3, m.Count("a"))
+	AssertEqual(t, 2, m.Count("b"))
+	AssertEqual(t, 0, m.Count("d"))
+	AssertEqual(t, 3, m.Len())
+	AssertEqual(t, 6, m.Total())
+	AssertSliceEqual(t, []string{"a", "b", "c"}, m.Keys())
+	AssertSliceEqual(t, []string{"a", "a", "a", "b", "b", "c"}, m.Elements())
+	AssertMapEqual(t, map[string]int{"a": 3, "b": 2, "c": 1}, m.ToMap())
+	AssertMapEqual(t, Counter([]string{"a", "b", "a", "c", "b", "a"}), m.ToMap())
+
+	m.Insert("d", "c")
+	AssertEqual(t, 2, m.Count("c"))
+	m.Set("a", 0)
+	AssertEqual(t, 0, m.Count("a"))
+	AssertSliceEqual(t, []string{"b", "c", "d"}, m.Keys())
+
+	// re-inserted value goes to the end
+	m.Insert("a")
+	AssertSliceEqual(t, []string{"b", "c", "d", "a"}, m.Keys())
+	m.Set("b", -1)
+	AssertSliceEqual(t, []string{"c", "d", "a"}, m.Keys())
+
+	var zero Multiset[int]
+	AssertEqual(t, 0, zero.Len())
+	AssertEqual(t, 0, zero.Count(1))
+	zero.Insert(1, 1)
+	AssertEqual(t, 2, zero.Count(1))
+
+	empty := NewMultiset[int]()
+	AssertSliceEqual(t, []int{}, empty.Elements())
+	AssertEqual(t, 0, empty.Total())
+	AssertEqual(t, 0, len(empty.MostCommon(3)))
+}
+
+func TestMultisetFromCounter(t *testing.T) {
+	counter := Counter([]string{"b", "a", "b", "c", "a"})
+	for i := 0; i < 10; i++ {
+		m := MultisetFromCounter(counter, func(a, b string) bool {
+			return a < b
+		})
+		AssertSliceEqual(t, []Pair[string, int]{{"a", 2}, {"b", 2}, {"c", 1}}, m.MostCommon(-1))
+		AssertSliceEqual(t, []string{"a", "b", "c"}, m.Keys())
+		AssertMapEqual(t, counter, m.ToMap())
+	}
+
+	ints := MultisetFromCounter(map[int]int{1: 1, 2: 0, 3: -1, 4: 1}, func(a, b int) bool {
+		return a > b
+	})
+	AssertMapEqual(t, map[int]int{1: 1, 4: 1}, ints.ToMap())
+	AssertSliceEqual(t, []Pair[int, int]{{4, 1}, {1, 1}}, ints.MostCommon(-1))
+}
+
+func TestMultisetFromCounterBy(t *testing.T) {
+	type Employee struct {
+		name       string
+		department string
+	}
+	employees := []Employee{
+		{"Alice", "Accounting"},
+		{"Bob", "Sales"},
+		{"Dave", "Engineering"},
+		{"Eve", "Engineering"},
+	}
+	department := func(e Employee) string {
+		return e.department
+	}
+	m := MultisetFromCounterBy(employees, department)
+	AssertSliceEqual(t, []Pair[string, int]{{"Engineering", 2}, {"Accounting", 1}}, m.MostCommon(2))
+	AssertSliceEqual(t, []string{"Accounting", "Sales", "Engineering"}, m.Keys())
+	AssertMapEqual(t, CounterBy(employees, department), m.ToMap())
+
+	AssertEqual(t, 0, MultisetFromCounterBy([]Employee{}, department).Len())
+}
+
+func TestMultisetMostCommon(t *testing.T) {
+	m := NewMultiset(5, 1, 3, 3, 1, 2, 4, 4, 4)
+	AssertSliceEqual(t, []Pair[int, int]{{4, 3}, {1, 2}, {3, 2}}, m.MostCommon(3))
+	AssertSliceEqual(t, []Pair[int, int]{{4, 3}, {1, 2}, {3, 2}, {5, 1}, {2, 1}}, m.MostCommon(-1))
+	AssertSliceEqual(t, []Pair[int, int]{{4, 3}, {1, 2}, {3, 2}, {5, 1}, {2, 1}}, m.MostCommon(100))
+	AssertSliceEqual(t, []Pair[int, int]{}, m.MostCommon(0))
+
+	// deterministic
+	for i := 0; i < 100; i++ {
+		m := NewMultiset("x", "y", "z", "w")
+		AssertSliceEqual(t, []Pair[string, int]{{"x", 1}, {"y", 1}}, m.MostCommon(2))
+	}
+}
+
+func TestMultisetAdd(t *testing.T) {
+	a := NewMultiset("a", "a", "b")
+	b := NewMultiset("c", "a")
+	add := a.Add(b)
+	AssertMapEqual(t, map[string]int{"a": 3, "b": 1, "c": 1}, add.ToMap())
+	AssertSliceEqual(t, []string{"a", "b", "c"}, add.Keys())
+	AssertMapEqual(t, map[string]int{"a": 1, "b": 1}, a.Subtract(b).ToMap())
+	AssertMapEqual(t, map[string]int{"c": 1}, b.Subtract(a).ToMap())
+	AssertMapEqual(t, map[string]int{"a": 2, "b": 1, "c": 1}, a.Union(b).ToMap())
+	AssertMapEqual(t, map[string]int{"a": 1}, a.Intersect(b).ToMap())
+	AssertSliceEqual(t, []string{"c", "a"}, b.Union(a).Keys()[:2])
+
+	// operands are not modified
+	AssertMapEqual(t, map[string]int{"a": 2, "b": 1}, a.ToMap())
+	AssertMapEqual(t, map[string]int{"a": 1, "c": 1}, b.ToMap())
+
+	empty := NewMultiset[string]()
+	AssertMapEqual(t, a.ToMap(), a.Add(empty).ToMap())
+	AssertMapEqual(t, map[string]int{}, a.Intersect(empty).ToMap())
+}
@@ -0,0 +1,436 @@
+package gfn
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+/* @example Histogram
+edges, counts := gfn.Histogram([]int{1, 2, 2, 3, 3, 3, 4}, 3)
+// edges:  []float64{1, 2, 3, 4}
+// counts: []int{1, 2, 4}
+*/
+
+// Histogram counts values of the array in bins of equal width between the
+// minimum and the maximum value. It returns bins+1 edges and bins counts.
+// Every bin is half-open [edges[i], edges[i+1]) except the last one, which
+// also includes the maximum. If all values are equal, the range is value-0.5
+// to value+0.5. NaN values are skipped, it panics if no value is left or
+// bins is not positive.
+func Histogram[T Int | Uint | Float](array []T, bins int) ([]float64, []int) {
+	if bins <= 0 {
+		panic("bins must be greater than 0")
+	}
+	values := floatValues(array)
+	if len(values) == 0 {
+		panic("array is empty")
+	}
+
+	minimum, maximum := MinMax(values...)
+	if minimum == maximum {
+		minimum -= 0.5
+		maximum += 0.5
+	}
+	edges := Linspace(minimum, maximum, bins+1, true)
+	return edges, HistogramEdges(array, edges)
+}
+
+/* @example HistogramEdges
+gfn.HistogramEdges([]float64{0.5, 1, 1.5, 7, 10, 12}, []float64{0, 1, 5, 10})
+// []int{1, 2, 2}, 12 is outside of the edges
+*/
+
+// HistogramEdges counts values of the array in bins defined by edges, which
+// must be sorted in ascending order and contain at least 2 values. It
+// returns len(edges)-1 counts. Bins are half-open like Histogram and the last
+// bin includes the last edge. Values outside of the edges and NaN values are
+// not counted.
+func HistogramEdges[T Int | Uint | Float](array []T, edges []float64) []int {
+	counter := Counter(Bucketize(array, edges))
+	counts := make([]int, len(edges)-1)
+	for i := range counts {
+		counts[i] = counter[i]
+	}
+	return counts
+}
+
+/* @example HistogramQuantile
+edges, counts := gfn.HistogramQuantile([]int{1, 2, 3, 4, 5, 6, 7, 8, 100}, 2)
+// edges:  []float64{1, 5, 100}
+// counts: []int{4, 5}
+*/
+
+// HistogramQuantile counts values of the array in bins holding roughly the
+// same number of values. The edges are the quantiles of the values,
+// calculated by linear interpolation between the sorted values. Repeated
+// values may produce repeated edges and empty bins. NaN values are skipped,
+// it panics if no value is left or bins is not positive.
+func HistogramQuantile[T Int | Uint | Float](array []T, bins int) ([]float64, []int) {
+	if bins <= 0 {
+		panic("bins must be greater than 0")
+	}
+	values := floatValues(array)
+	if len(values) == 0 {
+		panic("array is empty")
+	}
+
+	sort.Float64s(values)
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		pos := float64(i) / float64(bins) * float64(len(values)-1)
+		lower := int(math.Floor(pos))
+		upper := int(math.Ceil(pos))
+		edges[i] = values[lower] + (values[upper]-values[lower])*(pos-float64(lower))
+	}
+	edges[bins] = values[len(values)-1]
+	return edges, HistogramEdges(array, edges)
+}
+
+/* @example Bucketize
+gfn.Bucketize([]float64{-1, 0, 0.5, 1, 2, 3}, []float64{0, 1, 2})
+// []int{-1, 0, 0, 1, 1, -1}
+*/
+
+// Bucketize returns the index of the histogram bin of each value, using
+// the same bins as HistogramEdges. Values outside of the edges and NaN
+// values get -1. edges must be sorted in ascending order and contain at
+// least 2 values.
+func Bucketize[T Int | Uint | Float](array []T, edges []float64) []int {
+	checkEdges(edges)
+	last := edges[len(edges)-1]
+	return Map(array, func(v T) int {
+		x := float64(v)
+		if x == last {
+			// the last bin is closed
+			return len(edges) - 2
+		}
+		i := digitize(x, edges)
+		if i == 0 || i == len(edges) {
+			return -1
+		}
+		return i - 1
+	})
+}
+
+/* @example Digitize
+gfn.Digitize([]float64{-1, 0, 0.5, 1, 2, 3}, []float64{0, 1, 2})
+// []int{0, 1, 1, 2, 3, 3}
+*/
+
+// Digitize returns for each value the index i such that
+// edges[i-1] <= value < edges[i]. Values smaller than the first edge get 0,
+// values greater than or equal to the last edge and NaN values get
+// len(edges). edges must be sorted in ascending order.
+func Digitize[T Int | Uint | Float](array []T, edges []float64) []int {
+	if !IsSorted(edges) {
+		panic("edges must be sorted in ascending order")
+	}
+	return Map(array, func(v T) int {
+		return digitize(float64(v), edges)
+	})
+}
+
+// digitize returns the number of edges that are less than or equal to x,
+// or len(edges) for NaN.
+func digitize(x float64, edges []float64) int {
+	if math.IsNaN(x) {
+		return len(edges)
+	}
+	return sort.Search(len(edges), func(i int) bool {
+		return edges[i] > x
+	})
+}
+
+func checkEdges(edges []float64) {
+	if len(edges) < 2 {
+		panic("requires at least 2 edges")
+	}
+	if !IsSorted(edges) {
+		panic("edges must be sorted in ascending order")
+	}
+}
+
+// floatValues converts the array to float64 and skips NaN values.
+func floatValues[T Int | Uint | Float](array []T) []float64 {
+	res := make([]float64, 0, len(array))
+	for _, v := range array {
+		if !isNaN(v) {
+			res = append(res, float64(v))
+		}
+	}
+	return res
+}
+
+/* @example RenderHistogram
+edges, counts := gfn.Histogram([]int{1, 2, 2, 3, 3, 3, 4}, 3)
+fmt.Print(gfn.RenderHistogram(edges, counts, 20))
+// [1, 2) | #####                1
+// [2, 3) | ##########           2
+// [3, 4] | #################### 4
+*/
+
+// RenderHistogram renders a histogram as text, one line per bin with its
+// range, a bar and the count. The longest bar has width characters.
+func RenderHistogram(edges []float64, counts []int, width int) string {
+	if len(edges) != len(counts)+1 {
+		panic("edges must have one more element than counts")
+	}
+	if width <= 0 {
+		panic("width must be greater than 0")
+	}
+	if len(counts) == 0 {
+		return ""
+	}
+
+	labels := make([]string, len(counts))
+	for i := range counts {
+		closing := ")"
+		if i == len(counts)-1 {
+			closing = "]"
+		}
+		labels[i] = fmt.Sprintf("[%g, %g%s", edges[i], edges[i+1], closing)
+	}
+	labelWidth := Max(Map(labels, func(s string) int { return len(s) })...)
+	maxCount := Max(counts...)
+
+	sb := strings.Builder{}
+	for i, count := range counts {
+		bar := 0
+		if maxCount > 0 {
+			bar = int(math.Round(float64(count) / float64(maxCount) * float64(width)))
+		}
+		sb.WriteString(fmt.Sprintf("%-*s | %-*s %d\n", labelWidth, labels[i], width, strings.Repeat("#", bar), count))
+	}
+	return sb.String()
+}
+
+/* @example Covariance
+gfn.Covariance([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8})  // 3.3333333333333335
+*/
+
+// Covariance returns the sample covariance of two arrays of the same length,
+// which is divided by n-1. Pairs containing NaN are skipped, it panics if
+// fewer than 2 pairs are left.
+func Covariance[T Int | Uint | Float](x, y []T) float64 {
+	xs, ys := floatPairs(x, y)
+	return covariance(xs, ys)
+}
+
+/* @example CovarianceBy
+type Server struct {
+	users   int
+	latency float64
+}
+servers := []Server{{100, 10}, {200, 20}, {300, 40}}
+gfn.CovarianceBy(servers, func(s Server) int {
+	return s.users
+}, func(s Server) float64 {
+	return s.latency
+})  // 1500
+*/
+
+// CovarianceBy returns the sample covariance of the two values extracted
+// from every element of the array, like Covariance.
+func CovarianceBy[T any, U Int | Uint | Float, V Int | Uint | Float](array []T, fx func(T) U, fy func(T) V) float64 {
+	xs, ys := floatPairsBy(array, fx, fy)
+	return covariance(xs, ys)
+}
+
+/* @example Pearson
+gfn.Pearson([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8})  // 1
+gfn.Pearson([]int{1, 2, 3}, []int{3, 1, 2})                // -0.5
+*/
+
+// Pearson returns the Pearson correlation coefficient of two arrays of the
+// same length, between -1 and 1. Pairs containing NaN are skipped, it panics
+// if fewer than 2 pairs are left. If either array is constant, the
+// correlation is undefined and NaN is returned.
+func Pearson[T Int | Uint | Float](x, y []T) float64 {
+	xs, ys := floatPairs(x, y)
+	return pearson(xs, ys)
+}
+
+/* @example PearsonBy
+type Server struct {
+	users   int
+	latency float64
+}
+servers := []Server{{100, 10}, {200, 20}, {300, 30}}
+gfn.PearsonBy(servers, func(s Server) int {
+	return s.users
+}, func(s Server) float64 {
+	return s.latency
+})  // 1
+*/
+
+// PearsonBy returns the Pearson correlation coefficient of the two values
+// extracted from every element of the array, like Pearson.
+func PearsonBy[T any, U Int | Uint | Float, V Int | Uint | Float](array []T, fx func(T) U, fy func(T) V) float64 {
+	xs, ys := floatPairsBy(array, fx, fy)
+	return pearson(xs, ys)
+}
+
+/* @example Spearman
+gfn.Spearman([]float64{1, 2, 3, 4}, []float64{1, 10, 100, 1000})  // 1
+*/
+
+// Spearman returns the Spearman rank correlation coefficient of two arrays
+// of the same length, which is the Pearson correlation of their ranks. Tied
+// values get the average of their ranks. NaN values are handled like Pearson.
+func Spearman[T Int | Uint | Float](x, y []T) float64 {
+	xs, ys := floatPairs(x, y)
+	return pearson(averageRank(xs), averageRank(ys))
+}
+
+/* @example SpearmanBy
+type Server struct {
+	users   int
+	latency float64
+}
+servers := []Server{{100, 10}, {200, 50}, {300, 60}}
+gfn.SpearmanBy(servers, func(s Server) int {
+	return s.users
+}, func(s Server) float64 {
+	return s.latency
+})  // 1
+*/
+
+// SpearmanBy returns the Spearman rank correlation coefficient of the two
+// values extracted from every element of the array, like Spearman.
+func SpearmanBy[T any, U Int | Uint | Float, V Int | Uint | Float](array []T, fx func(T) U, fy func(T) V) float64 {
+	xs, ys := floatPairsBy(array, fx, fy)
+	return pearson(averageRank(xs), averageRank(ys))
+}
+
+/* @example LinearRegression
+slope, intercept, r2 := gfn.LinearRegression([]float64{1, 2, 3}, []float64{3, 5, 7})
+// slope: 2, intercept: 1, r2: 1
+*/
+
+// LinearRegression fits y = slope * x + intercept with ordinary least
+// squares and returns the slope, the intercept and the coefficient of
+// determination r². Pairs containing NaN are skipped, it panics if fewer
+// than 2 pairs are left or all x values are equal. If all y values are
+// equal, r² is undefined and NaN is returned.
+func LinearRegression[T Int | Uint | Float](x, y []T) (float64, float64, float64) {
+	xs, ys := floatPairs(x, y)
+	return linearRegression(xs, ys)
+}
+
+/* @example LinearRegressionBy
+type Server struct {
+	users   int
+	latency float64
+}
+servers := []Server{{100, 12}, {200, 22}, {300, 32}}
+slope, intercept, r2 := gfn.LinearRegressionBy(servers, func(s Server) int {
+	return s.users
+}, func(s Server) float64 {
+	return s.latency
+})
+// slope: 0.1, intercept: 2, r2: 1
+*/
+
+// LinearRegressionBy fits the second extracted value against the first one
+// for every element of the array, like LinearRegression.
+func LinearRegressionBy[T any, U Int | Uint | Float, V Int | Uint | Float](array []T, fx func(T) U, fy func(T) V) (float64, float64, float64) {
+	xs, ys := floatPairsBy(array, fx, fy)
+	return linearRegression(xs, ys)
+}
+
+// floatPairs converts both arrays to float64 and skips pairs containing NaN.
+func floatPairs[T Int | Uint | Float](x, y []T) ([]float64, []float64) {
+	checkSameLength(x, y)
+	xs := make([]float64, 0, len(x))
+	ys := make([]float64, 0, len(y))
+	for i := range x {
+		if isNaN(x[i]) || isNaN(y[i]) {
+			continue
+		}
+		xs = append(xs, float64(x[i]))
+		ys = append(ys, float64(y[i]))
+	}
+	return xs, ys
+}
+
+func floatPairsBy[T any, U Int | Uint | Float, V Int | Uint | Float](array []T, fx func(T) U, fy func(T) V) ([]float64, []float64) {
+	xs := make([]float64, 0, len(array))
+	ys := make([]float64, 0, len(array))
+	for _, v := range array {
+		x, y := float64(fx(v)), float64(fy(v))
+		if isNaN(x) || isNaN(y) {
+			continue
+		}
+		xs = append(xs, x)
+		ys = append(ys, y)
+	}
+	return xs, ys
+}
+
+// sumOfProducts returns the means of xs and ys and the sums of
+// (x-meanX)*(y-meanY), (x-meanX)² and (y-meanY)².
+func sumOfProducts(xs, ys []float64) (meanX, meanY, sxy, sxx, syy float64) {
+	if len(xs) < 2 {
+		panic("requires at least 2 pairs")
+	}
+
+	meanX, meanY = Mean(xs...), Mean(ys...)
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	return meanX, meanY, sxy, sxx, syy
+}
+
+func covariance(xs, ys []float64) float64 {
+	_, _, sxy, _, _ := sumOfProducts(xs, ys)
+	return sxy / float64(len(xs)-1)
+}
+
+func pearson(xs, ys []float64) float64 {
+	_, _, sxy, sxx, syy := sumOfProducts(xs, ys)
+	if sxx == 0 || syy == 0 {
+		return math.NaN()
+	}
+	r := sxy / math.Sqrt(sxx*syy)
+	// rounding errors may push r slightly out of [-1, 1]
+	return math.Max(-1, math.Min(1, r))
+}
+
+func linearRegression(xs, ys []float64) (float64, float64, float64) {
+	meanX, meanY, sxy, sxx, syy := sumOfProducts(xs, ys)
+	if sxx == 0 {
+		panic("x values are all equal")
+	}
+
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+	if syy == 0 {
+		return slope, intercept, math.NaN()
+	}
+	return slope, intercept, math.Min(1, sxy*sxy/(sxx*syy))
+}
+
+// averageRank returns the ranks of the values starting from 1, tied values
+// get the average of their ranks.
+func averageRank(values []float64) []float64 {
+	order := ArgSort(values)
+	res := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j < len(order) && values[order[j]] == values[order[i]] {
+			j++
+		}
+		// positions i to j-1 are tied, their ranks are i+1 to j
+		rank := float64(i+j+1) / 2
+		for _, idx := range order[i:j] {
+			res[idx] = rank
+		}
+		i = j
+	}
+	return res
+}
@@ -0,0 +1,246 @@
+package gfn_test
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	. "github.com/suchen-sci/gfn"
+)
+
+func TestHistogram(t *testing.T) {
+	edges, counts := Histogram([]int{1, 2, 2, 3, 3, 3, 4}, 3)
+	AssertFloatSliceEqual(t, []float64{1, 2, 3, 4}, edges)
+	AssertSliceEqual(t, []int{1, 2, 4}, counts)
+
+	edges, counts = Histogram([]float64{0, 0.1, 0.9, 1, math.NaN()}, 2)
+	AssertFloatSliceEqual(t, []float64{0, 0.5, 1}, edges)
+	AssertSliceEqual(t, []int{2, 2}, counts)
+
+	edges, counts = Histogram([]uint{5, 5, 5}, 1)
+	AssertFloatSliceEqual(t, []float64{4.5, 5.5}, edges)
+	AssertSliceEqual(t, []int{3}, counts)
+
+	// every value is counted
+	for i := 0; i < 100; i++ {
+		array := make([]float64, rand.Intn(100)+1)
+		for j := range array {
+			array[j] = rand.NormFloat64()
+		}
+		bins := rand.Intn(10) + 1
+		edges, counts := Histogram(array, bins)
+		AssertEqual(t, bins+1, len(edges))
+		AssertEqual(t, len(array), Sum(counts...))
+	}
+
+	AssertPanics(t, func() {
+		Histogram([]int{}, 2)
+	})
+	AssertPanics(t, func() {
+		Histogram([]float64{math.NaN()}, 2)
+	})
+	AssertPanics(t, func() {
+		Histogram([]int{1, 2}, 0)
+	})
+}
+
+func TestHistogramEdges(t *testing.T) {
+	AssertSliceEqual(t, []int{1, 2, 2}, HistogramEdges([]float64{0.5, 1, 1.5, 7, 10, 12}, []float64{0, 1, 5, 10}))
+	AssertSliceEqual(t, []int{0, 0}, HistogramEdges([]int{}, []float64{0, 1, 2}))
+	AssertSliceEqual(t, []int{2, 1}, HistogramEdges([]int{-1, 0, 0, 1, 3}, []float64{0, 1, 1}))
+
+	AssertPanics(t, func() {
+		HistogramEdges([]int{1}, []float64{1})
+	})
+	AssertPanics(t, func() {
+		HistogramEdges([]int{1}, []float64{2, 1})
+	})
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	edges, counts := HistogramQuantile([]int{1, 2, 3, 4, 5, 6, 7, 8, 100}, 2)
+	AssertFloatSliceEqual(t, []float64{1, 5, 100}, edges)
+	AssertSliceEqual(t, []int{4, 5}, counts)
+
+	edges, counts = HistogramQuantile([]float64{4, 3, 2, 1, math.NaN()}, 4)
+	AssertFloatSliceEqual(t, []float64{1, 1.75, 2.5, 3.25, 4}, edges)
+	AssertSliceEqual(t, []int{1, 1, 1, 1}, counts)
+
+	edges, counts = HistogramQuantile([]int{1, 1, 1, 2}, 2)
+	AssertFloatSliceEqual(t, []float64{1, 1, 2}, edges)
+	AssertSliceEqual(t, []int{0, 4}, counts)
+
+	AssertPanics(t, func() {
+		HistogramQuantile([]int{}, 2)
+	})
+	AssertPanics(t, func() {
+		HistogramQuantile([]int{1}, -1)
+	})
+}
+
+func TestBucketize(t *testing.T) {
+	AssertSliceEqual(t, []int{-1, 0, 0, 1, 1, -1}, Bucketize([]float64{-1, 0, 0.5, 1, 2, 3}, []float64{0, 1, 2}))
+	AssertSliceEqual(t, []int{-1}, Bucketize([]float64{math.NaN()}, []float64{0, 1, 2}))
+	AssertSliceEqual(t, []int{0, 1, 2}, Bucketize([]uint8{0, 10, 255}, []float64{0, 10, 100, 255}))
+	AssertSliceEqual(t, []int{}, Bucketize([]int{}, []float64{0, 1}))
+
+	AssertPanics(t, func() {
+		Bucketize([]int{1}, []float64{})
+	})
+}
+
+func TestDigitize(t *testing.T) {
+	AssertSliceEqual(t, []int{0, 1, 1, 2, 3, 3}, Digitize([]float64{-1, 0, 0.5, 1, 2, 3}, []float64{0, 1, 2}))
+	AssertSliceEqual(t, []int{3}, Digitize([]float64{math.NaN()}, []float64{0, 1, 2}))
+	AssertSliceEqual(t, []int{0, 0}, Digitize([]int{1, 2}, []float64{}))
+	AssertSliceEqual(t, []int{0, 3, 3}, Digitize([]int{0, 1, 2}, []float64{1, 1, 1}))
+
+	AssertPanics(t, func() {
+		Digitize([]int{1}, []float64{2, 1})
+	})
+}
+
+func TestRenderHistogram(t *testing.T) {
+	edges, counts := Histogram([]int{1, 2, 2, 3, 3, 3, 4}, 3)
+	expected := strings.Join([]string{
+		"[1, 2) | #####                1",
+		"[2, 3) | ##########           2",
+		"[3, 4] | #################### 4",
+		"",
+	}, "\n")
+	AssertEqual(t, expected, RenderHistogram(edges, counts, 20))
+
+	expected = strings.Join([]string{
+		"[0.5, 10) |    0",
+		"[10, 100] | ## 2",
+		"",
+	}, "\n")
+	AssertEqual(t, expected, RenderHistogram([]float64{0.5, 10, 100}, []int{0, 2}, 2))
+	AssertEqual(t, "[0, 1] |    0\n", RenderHistogram([]float64{0, 1}, []int{0}, 2))
+	AssertEqual(t, "", RenderHistogram([]float64{0}, []int{}, 2))
+
+	AssertPanics(t, func() {
+		RenderHistogram([]float64{0, 1}, []int{1, 2}, 10)
+	})
+	AssertPanics(t, func() {
+		RenderHistogram([]float64{0, 1}, []int{1}, 0)
+	})
+}
+
+type server struct {
+	users   int
+	latency float64
+}
+
+func TestCovariance(t *testing.T) {
+	AssertFloatEqual(t, 10.0/3, Covariance([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8}))
+	AssertFloatEqual(t, -1, Covariance([]int{1, 2, 3}, []int{3, 2, 1}))
+	AssertFloatEqual(t, 0, Covariance([]int{1, 2, 3}, []int{5, 5, 5}))
+	AssertFloatEqual(t, 0.25, Covariance([]float64{1, math.NaN(), 2, 3}, []float64{1, 2, 1.5, math.NaN()}))
+
+	AssertPanics(t, func() {
+		Covariance([]int{1}, []int{1})
+	})
+	AssertPanics(t, func() {
+		Covariance([]int{1, 2}, []int{1})
+	})
+	AssertPanics(t, func() {
+		Covariance([]float64{1, math.NaN()}, []float64{1, 2})
+	})
+}
+
+func TestCovarianceBy(t *testing.T) {
+	servers := []server{{100, 10}, {200, 20}, {300, 40}}
+	AssertFloatEqual(t, 1500, CovarianceBy(servers, func(s server) int {
+		return s.users
+	}, func(s server) float64 {
+		return s.latency
+	}))
+
+	AssertPanics(t, func() {
+		CovarianceBy([]server{}, func(s server) int {
+			return s.users
+		}, func(s server) float64 {
+			return s.latency
+		})
+	})
+}
+
+func TestPearson(t *testing.T) {
+	AssertFloatEqual(t, 1, Pearson([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8}))
+	AssertFloatEqual(t, -1, Pearson([]float64{1, 2, 3, 4}, []float64{8, 6, 4, 2}))
+	AssertFloatEqual(t, -0.5, Pearson([]int{1, 2, 3}, []int{3, 1, 2}))
+	AssertFloatEqual(t, 1, Pearson([]float64{1, 2, math.NaN(), 3}, []float64{1, 2, 0, 3}))
+	AssertTrue(t, math.IsNaN(Pearson([]int{1, 2, 3}, []int{5, 5, 5})))
+
+	AssertPanics(t, func() {
+		Pearson([]int{1}, []int{2})
+	})
+}
+
+func TestPearsonBy(t *testing.T) {
+	servers := []server{{100, 10}, {200, 20}, {300, 30}}
+	AssertFloatEqual(t, 1, PearsonBy(servers, func(s server) int {
+		return s.users
+	}, func(s server) float64 {
+		return s.latency
+	}))
+}
+
+func TestSpearman(t *testing.T) {
+	AssertFloatEqual(t, 1, Spearman([]float64{1, 2, 3, 4}, []float64{1, 10, 100, 1000}))
+	AssertFloatEqual(t, -1, Spearman([]int{1, 2, 3, 4}, []int{9, 4, 1, 0}))
+	// ranks with ties: x = [1, 2.5, 2.5, 4], y = [1, 2, 3, 4]
+	AssertFloatEqual(t, 0.9486833, Spearman([]int{1, 2, 2, 3}, []int{1, 2, 3, 4}))
+	AssertTrue(t, math.IsNaN(Spearman([]int{1, 1}, []int{1, 2})))
+
+	AssertPanics(t, func() {
+		Spearman([]int{}, []int{})
+	})
+}
+
+func TestSpearmanBy(t *testing.T) {
+	servers := []server{{100, 10}, {200, 50}, {300, 60}}
+	AssertFloatEqual(t, 1, SpearmanBy(servers, func(s server) int {
+		return s.users
+	}, func(s server) float64 {
+		return s.latency
+	}))
+}
+
+func TestLinearRegression(t *testing.T) {
+	slope, intercept, r2 := LinearRegression([]float64{1, 2, 3}, []float64{3, 5, 7})
+	AssertFloatEqual(t, 2, slope)
+	AssertFloatEqual(t, 1, intercept)
+	AssertFloatEqual(t, 1, r2)
+
+	slope, intercept, r2 = LinearRegression([]int{0, 1, 2, 3}, []int{1, 3, 2, 4})
+	AssertFloatEqual(t, 0.8, slope)
+	AssertFloatEqual(t, 1.3, intercept)
+	AssertFloatEqual(t, 0.64, r2)
+
+	slope, intercept, r2 = LinearRegression([]int{1, 2, 3}, []int{4, 4, 4})
+	AssertFloatEqual(t, 0, slope)
+	AssertFloatEqual(t, 4, intercept)
+	AssertTrue(t, math.IsNaN(r2))
+
+	AssertPanics(t, func() {
+		LinearRegression([]int{2, 2, 2}, []int{1, 2, 3})
+	})
+	AssertPanics(t, func() {
+		LinearRegression([]int{1}, []int{1})
+	})
+}
+
+func TestLinearRegressionBy(t *testing.T) {
+	servers := []server{{100, 12}, {200, 22}, {300, 32}}
+	slope, intercept, r2 := LinearRegressionBy(servers, func(s server) int {
+		return s.users
+	}, func(s server) float64 {
+		return s.latency
+	})
+	AssertFloatEqual(t, 0.1, slope)
+	AssertFloatEqual(t, 2, intercept)
+	AssertFloatEqual(t, 1, r2)
+}
@@ -26,10 +26,35 @@ func AssertEqual[T comparable](t *testing.T, expected T, actual T, tags ...strin
 	}
 }
 
+// floatEqual reports whether two floats differ by at most 0.0001, NaN is
+// only equal to NaN. It does not use the library, so bugs there cannot hide
+// in the assertions.
+func floatEqual(expected, actual float64) bool {
+	if math.IsNaN(expected) || math.IsNaN(actual) {
+		return math.IsNaN(expected) && math.IsNaN(actual)
+	}
+	return !(math.Abs(expected-actual) > 0.0001)
+}
+
 func AssertFloatEqual[T Float](t *testing.T, expected T, actual T, tags ...string) {
 	t.Helper()
-	if math.Abs(float64(expected-actual)) > 0.0001 {
+	if !floatEqual(float64(expected), float64(actual)) {
+		fail(t, fmt.Sprintf("expected: %v, actual: %v", expected, actual), tags...)
+	}
+}
+
+func AssertFloatSliceEqual[T Float](t *testing.T, expected []T, actual []T, tags ...string) {
+	t.Helper()
+	if len(expected) != len(actual) {
 		fail(t, fmt.Sprintf("expected: %v, actual: %v", expected, actual), tags...)
+		return
+	}
+
+	for i := 0; i < len(expected); i++ {
+		if !floatEqual(float64(expected[i]), float64(actual[i])) {
+			fail(t, fmt.Sprintf("expected: %v, actual: %v", expected, actual), tags...)
+			return
+		}
 	}
 }
 
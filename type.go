@@ -23,11 +23,44 @@ type Float interface {
 	~float32 | ~float64
 }
 
-// Complex contains complex types.
+// Complex contains complex types. Complex values have no natural order, so
+// functions based on ordering such as Max, Min and IsSorted reject them at
+// compile time. Order them by a real-valued key instead, for example
+// MaxBy(array, AbsComplex[complex128]) for the largest magnitude or
+// MaxBy(array, func(c complex128) float64 { return real(c) }) for the largest
+// real part.
 type Complex interface {
 	~complex64 | ~complex128
 }
 
+// Adder contains types whose Add method returns the sum of the receiver and
+// another value without modifying either of them, such as Decimal.
+type Adder[T any] interface {
+	Add(T) T
+}
+
+// Averager contains Adder types whose DivInt method returns the receiver
+// divided by an integer, such as Decimal. The pointer types of math/big have
+// no DivInt, please use MeanBig for them.
+type Averager[T any] interface {
+	Adder[T]
+	DivInt(int) T
+}
+
+// Comparer contains types whose Cmp method returns -1, 0 or +1 if the
+// receiver is less than, equal to or greater than another value, such as
+// Decimal, *big.Int, *big.Float and *big.Rat.
+type Comparer[T any] interface {
+	Cmp(T) int
+}
+
+// BigAdder contains pointer types whose Add method sets the receiver to the
+// sum of two values and returns it, such as *big.Int, *big.Float and *big.Rat.
+type BigAdder[E any, T any] interface {
+	*E
+	Add(T, T) T
+}
+
 // Pair is a generic pair of values.
 type Pair[T, U any] struct {
 	First  T